@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/remote"
@@ -10,22 +11,55 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	internalActor "reddit-clone/internal/actor" // Alias the import
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/store"
+	"reddit-clone/internal/store/bolt"
 	"reddit-clone/internal/store/memory"
 	"reddit-clone/pkg/metrics"
 )
 
 func main() {
+	storeKind := flag.String("store", "memory", "storage backend: memory, wal or bolt")
+	dbPath := flag.String("db", "reddit.db", "database file used by the bolt store")
+	dataDir := flag.String("data-dir", "data", "directory holding the wal store's log and snapshots")
+	snapshotEvery := flag.Int("snapshot-every", 10000, "writes between wal store snapshots (0 disables)")
+	syncWrites := flag.Bool("sync", true, "fsync the wal store's log after every write")
+	tokenSecret := flag.String("token-secret", os.Getenv("REDDIT_TOKEN_SECRET"), "key used to sign session tokens (random if empty)")
+	host := flag.String("host", "127.0.0.1", "address the engine listens on")
+	port := flag.Int("port", 8090, "port the engine listens on")
+	metricsAddr := flag.String("metrics", ":2112", "address of the Prometheus metrics endpoint")
+	peers := flag.String("peers", "", "comma-separated host:manage-port of every cluster member, including this one; enables cluster mode")
+	managePort := flag.Int("manage-port", 6330, "port serving cluster membership in cluster mode")
+	clusterName := flag.String("cluster", "reddit", "cluster name in cluster mode")
+	eventsFile := flag.String("events-file", "", "append every domain event to this NDJSON file")
+	supervision := internalActor.DefaultSupervision()
+	flag.IntVar(&supervision.MaxRestarts, "max-restarts", supervision.MaxRestarts, "restarts a failing actor gets within -restart-window before it is stopped")
+	flag.DurationVar(&supervision.Window, "restart-window", supervision.Window, "window in which -max-restarts is counted")
+	flag.DurationVar(&supervision.Backoff, "restart-backoff", supervision.Backoff, "delay added to a restart for every recent failure (0 restarts at once)")
+	flag.Parse()
+
+	dataStore, closeStore, err := openStore(*storeKind, *dbPath, *dataDir, memory.DurableOptions{
+		SnapshotEvery: *snapshotEvery,
+		SyncWrites:    *syncWrites,
+	})
+	if err != nil {
+		log.Fatalf("Failed to open %s store: %v", *storeKind, err)
+	}
+
 	// Initialize metrics
 	metricsCollector := metrics.NewRedditMetrics()
 
 	// Start metrics endpoint
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
-		if err := http.ListenAndServe(":2112", nil); err != nil {
+		if err := http.ListenAndServe(*metricsAddr, nil); err != nil {
 			log.Fatalf("Failed to start metrics server: %v", err)
 		}
 	}()
@@ -33,28 +67,64 @@ func main() {
 	// Initialize actor system
 	system := actor.NewActorSystem()
 
-	// Configure remote
-	remoteConfig := remote.Configure("127.0.0.1", 8090)
-
-	// Create remote
-	remoting := remote.NewRemote(system, remoteConfig)
+	closeEvents := func() error { return nil }
+	if *eventsFile != "" {
+		sink, err := events.OpenNDJSONFile(*eventsFile)
+		if err != nil {
+			log.Fatalf("Failed to open events file: %v", err)
+		}
+		events.Attach(system.EventStream, sink)
+		closeEvents = sink.Close
+	}
 
 	// Create new engine actor
+	var engineOpts []internalActor.EngineOption
+	if *tokenSecret != "" {
+		engineOpts = append(engineOpts, internalActor.WithTokenManager(
+			auth.NewTokenManager([]byte(*tokenSecret), 24*time.Hour),
+		))
+	}
+
 	engineActor := internalActor.NewEngineActor(
-		memory.NewMemoryStore(),
+		dataStore,
 		metricsCollector,
+		engineOpts...,
 	)
 
+	// The supervisor restarts the engine's subreddit and user actors when they
+	// panic, and through the root guardian the engine itself.
+	supervisor := internalActor.NewSupervisor(supervision, metricsCollector)
+
 	// Create props
 	props := actor.PropsFromProducer(func() actor.Actor {
 		return engineActor
-	})
-
-	remoting.Register("engine", props)
-	remoting.Start()
+	}, actor.WithSupervisor(supervisor))
+
+	// In cluster mode users and subreddits are grains spread over the members
+	// and this node's engine forwards to them; otherwise they are children of
+	// the engine.
+	var shutdownRemote func()
+	if *peers != "" {
+		if *tokenSecret == "" {
+			log.Fatalf("Cluster mode needs -token-secret so that every member accepts the same sessions")
+		}
+		c := engineActor.StartClusterMember(system, internalActor.ClusterConfig{
+			Name:       *clusterName,
+			Host:       *host,
+			Port:       *port,
+			ManagePort: *managePort,
+			Peers:      strings.Split(*peers, ","),
+		})
+		shutdownRemote = func() { c.Shutdown(true) }
+	} else {
+		remoting := remote.NewRemote(system, remote.Configure(*host, *port))
+		remoting.Register("engine", props)
+		remoting.Start()
+		shutdownRemote = func() { remoting.Shutdown(true) }
+	}
 
 	// Spawn the engine actor
-	pid, err := system.Root.SpawnNamed(props, "engine")
+	pid, err := system.Root.WithGuardian(supervisor).SpawnNamed(props, "engine")
 	if err != nil {
 		log.Fatalf("Failed to spawn engine actor: %v", err)
 	}
@@ -85,11 +155,18 @@ func main() {
 		cancel() // Signal all components to shut down
 
 		fmt.Println("Stopping remoting...")
-		remoting.Shutdown(true)
+		shutdownRemote()
 		
 		fmt.Println("Shutting down actor system...")
 		system.Shutdown()
 
+		if err := closeStore(); err != nil {
+			log.Printf("Failed to close store: %v", err)
+		}
+		if err := closeEvents(); err != nil {
+			log.Printf("Failed to close events file: %v", err)
+		}
+
 		fmt.Println("Shutdown complete.")
 		os.Exit(0)
 	}()
@@ -97,3 +174,26 @@ func main() {
 	// Keep the process running until shutdown is triggered
 	select {}
 }
+
+// openStore creates the storage backend selected on the command line along
+// with a function that flushes and releases it on shutdown.
+func openStore(kind, path, dataDir string, opts memory.DurableOptions) (store.Store, func() error, error) {
+	switch kind {
+	case "memory":
+		return memory.NewMemoryStore(), func() error { return nil }, nil
+	case "wal":
+		durableStore, err := memory.OpenDurableStore(dataDir, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return durableStore, durableStore.Close, nil
+	case "bolt":
+		boltStore, err := bolt.NewBoltStore(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return boltStore, boltStore.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown store %q", kind)
+	}
+}
@@ -0,0 +1,59 @@
+// cmd/gateway/main.go
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+
+	"reddit-clone/internal/gateway"
+)
+
+func main() {
+	listenAddr := flag.String("listen", ":8080", "address the HTTP API listens on")
+	engineAddr := flag.String("engine", "127.0.0.1:8090", "remote address of the engine actor system")
+	remoteHost := flag.String("remote-host", "127.0.0.1", "host the gateway's actor system binds to")
+	remotePort := flag.Int("remote-port", 8092, "port the gateway's actor system binds to")
+	timeout := flag.Duration("timeout", 5*time.Second, "how long to wait for an engine reply")
+	flag.Parse()
+
+	// Initialize actor system
+	system := actor.NewActorSystem()
+
+	// Configure remote
+	remoteConfig := remote.Configure(*remoteHost, *remotePort)
+	remoting := remote.NewRemote(system, remoteConfig)
+	remoting.Start()
+
+	enginePID := actor.NewPID(*engineAddr, "engine")
+	api := gateway.NewGateway(system.Root, enginePID, *timeout)
+
+	server := &http.Server{
+		Addr:    *listenAddr,
+		Handler: api,
+	}
+
+	go func() {
+		log.Printf("Gateway listening on %s, forwarding to engine at %s", *listenAddr, enginePID.Address)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start HTTP server: %v", err)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigChan
+	log.Printf("Received signal: %v", sig)
+
+	server.Close()
+	remoting.Shutdown(true)
+	system.Shutdown()
+	log.Println("Shutdown complete.")
+}
@@ -2,6 +2,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/remote"
@@ -9,6 +11,7 @@ import (
 	"log"
 	"net/http"
 	pb "reddit-clone/api/proto/generated"
+	internalActor "reddit-clone/internal/actor"
 	"reddit-clone/internal/simulation"
 	"reddit-clone/pkg/metrics"
 	"strings"
@@ -16,13 +19,20 @@ import (
 )
 
 func main() {
+	engineAddr := flag.String("engine", "127.0.0.1:8090", "address of the engine, or of any member in cluster mode")
+	port := flag.Int("port", 8091, "port the simulator listens on")
+	metricsAddr := flag.String("metrics", ":2113", "address of the Prometheus metrics endpoint")
+	peers := flag.String("peers", "", "comma-separated host:manage-port of every cluster member; sends requests to grains directly")
+	clusterName := flag.String("cluster", "reddit", "cluster name in cluster mode")
+	flag.Parse()
+
 	// Initialize metrics collector
 	metricsCollector := metrics.NewRedditMetrics()
 
 	// Start metrics endpoint
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
-		if err := http.ListenAndServe(":2113", nil); err != nil {
+		if err := http.ListenAndServe(*metricsAddr, nil); err != nil {
 			log.Fatalf("Failed to start metrics server: %v", err)
 		}
 	}()
@@ -30,10 +40,22 @@ func main() {
 	// Initialize actor system
 	system := actor.NewActorSystem()
 
-	// Configure remote
-	remoteConfig := remote.Configure("127.0.0.1", 8091)
-	remoting := remote.NewRemote(system, remoteConfig)
-	remoting.Start()
+	// Configure remote. A cluster client brings its own remote and resolves
+	// the grain that owns each request.
+	var clientOpts []internalActor.ClientOption
+	if *peers != "" {
+		grains := internalActor.StartClusterClient(system, internalActor.ClusterConfig{
+			Name:  *clusterName,
+			Host:  "127.0.0.1",
+			Port:  *port,
+			Peers: strings.Split(*peers, ","),
+		})
+		clientOpts = append(clientOpts, internalActor.WithGrains(grains))
+	} else {
+		remoteConfig := remote.Configure("127.0.0.1", *port)
+		remoting := remote.NewRemote(system, remoteConfig)
+		remoting.Start()
+	}
 
 	// Wait for engine to be available
 	time.Sleep(10 * time.Second)
@@ -41,7 +63,7 @@ func main() {
 	//// Connect to engine
 	//enginePID := actor.NewPID("localhost:8090", "engine")
 	// Create engine PID
-	enginePID := actor.NewPID("127.0.0.1:8090", "engine")
+	enginePID := actor.NewPID(*engineAddr, "engine")
 	log.Printf("Initializing connection to engine at %s...", enginePID.Address)
 	// Verify engine connection
 
@@ -70,7 +92,7 @@ func main() {
 
 	log.Println("Successfully connected to engine")
 	// Create simulation controller
-	controller := simulation.NewSimulationController(system, enginePID, metricsCollector)
+	controller := simulation.NewSimulationController(system, enginePID, metricsCollector, clientOpts...)
 
 	// Start simulation with 1000 clients
 	if err := controller.Start(10); err != nil {
@@ -98,7 +120,7 @@ func verifyEngineConnection(system *actor.ActorSystem, enginePID *actor.PID, tim
 	result, err := future.Result()
 	if err != nil {
 		switch {
-		case strings.Contains(err.Error(), "future: timeout"):
+		case errors.Is(err, actor.ErrTimeout):
 			log.Fatalf("Connection timed out after %v: engine might be down or unreachable", timeout)
 		case strings.Contains(err.Error(), "msg must be proto.Message"):
 			log.Fatalf("Message serialization failed: %v (check protobuf message definitions)", err)
@@ -106,6 +128,9 @@ func verifyEngineConnection(system *actor.ActorSystem, enginePID *actor.PID, tim
 			log.Fatalf("Connection failed: %v", err)
 		}
 	}
+	if resp, ok := result.(*pb.ErrorResponse); ok {
+		return fmt.Errorf("engine answered ping with %s: %s", resp.Code, resp.Error)
+	}
 	if _, ok := result.(*pb.PongMessage); !ok {
 		return fmt.Errorf("unexpected response type: %T (expected PongMessage)", result)
 	}
@@ -0,0 +1,253 @@
+package actor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// Account requests are handled by the user's grain. Deleting an account also
+// leaves the user's subreddits, by asking each subreddit's actor, and
+// rewrites their posts and comments, which other grains own; those writes go
+// straight to the store, which applies each of them atomically. In cluster
+// mode every other member does the same for the content it stores, and
+// exports gather the user's content from every member.
+
+const (
+	maxDisplayNameLength = 30
+	maxBioLength         = 200
+)
+
+// userArchive is the JSON document ExportUserDataMessage is answered with.
+type userArchive struct {
+	User             *models.User            `json:"user"`
+	Subreddits       []string                `json:"subreddits"`
+	Posts            []*models.Post          `json:"posts"`
+	Comments         []*models.Comment       `json:"comments"`
+	Votes            map[string]bool         `json:"votes"` // target ID -> upvote
+	MessagesReceived []*models.DirectMessage `json:"messages_received"`
+	MessagesSent     []*models.DirectMessage `json:"messages_sent"`
+	ExportedAt       int64                   `json:"exported_at"`
+}
+
+// storedContent adds the posts, comments and votes of userID in the store to
+// archive.
+func (e *engineCore) storedContent(userID string, archive *userArchive) error {
+	posts, err := e.store.GetUserPosts(userID)
+	var comments []*models.Comment
+	if err == nil {
+		comments, err = e.store.GetUserComments(userID)
+	}
+	var votes map[string]bool
+	if err == nil {
+		votes, err = e.store.GetUserVotes(userID)
+	}
+	if err != nil {
+		return err
+	}
+	archive.Posts = append(archive.Posts, posts...)
+	archive.Comments = append(archive.Comments, comments...)
+	if archive.Votes == nil {
+		archive.Votes = make(map[string]bool, len(votes))
+	}
+	for targetID, isUpvote := range votes {
+		archive.Votes[targetID] = isUpvote
+	}
+	return nil
+}
+
+// gatherContent adds what the other cluster members store of userID's posts,
+// comments and votes to archive.
+func (e *engineCore) gatherContent(context actor.Context, userID string, archive *userArchive) error {
+	answers, err := e.askOtherMembers(context, &pb.ExportUserContentMessage{UserId: userID})
+	if err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		export, ok := answer.(*pb.UserDataExport)
+		if !ok {
+			return fmt.Errorf("unexpected answer %T", answer)
+		}
+		part := &userArchive{}
+		if err := json.Unmarshal(export.Archive, part); err != nil {
+			return err
+		}
+		archive.Posts = append(archive.Posts, part.Posts...)
+		archive.Comments = append(archive.Comments, part.Comments...)
+		for targetID, isUpvote := range part.Votes {
+			archive.Votes[targetID] = isUpvote
+		}
+	}
+	store.SortPosts(archive.Posts)
+	store.SortComments(archive.Comments)
+	return nil
+}
+
+func (e *engineCore) handleUpdateProfile(context actor.Context, msg *pb.UpdateProfileMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+
+	var err error
+	switch {
+	case utf8.RuneCountInString(msg.DisplayName) > maxDisplayNameLength:
+		err = invalidf("display name is longer than %d characters", maxDisplayNameLength)
+	case utf8.RuneCountInString(msg.Bio) > maxBioLength:
+		err = invalidf("bio is longer than %d characters", maxBioLength)
+	default:
+		err = e.store.UpdateProfile(msg.UserId, msg.DisplayName, msg.Bio)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Profile updated successfully"})
+}
+
+// handleChangePassword replaces a user's password and logs out every session
+// of the user, answering with a new one.
+func (e *engineCore) handleChangePassword(context actor.Context, msg *pb.ChangePasswordMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+	if msg.NewPassword == "" {
+		e.fail(context, invalidf("new password is required"))
+		return
+	}
+	if err := e.checkPassword(msg.UserId, msg.Password); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	// Every session issued before the new one is revoked.
+	token, claims, err := e.tokens.Issue(msg.UserId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	hash, err := auth.HashPassword(msg.NewPassword)
+	if err == nil {
+		err = e.store.SetPassword(msg.UserId, hash)
+	}
+	if err == nil {
+		err = e.store.RevokeSessions(msg.UserId, claims.IssuedAt)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.LoginResponse{
+		UserId:    msg.UserId,
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt,
+	})
+}
+
+// handleDeleteAccount deletes a user's account after checking their
+// password. They leave every subreddit, while their session is still valid,
+// and are logged out everywhere. If a subreddit cannot be left, the account
+// is kept and the request can be retried.
+func (e *engineCore) handleDeleteAccount(context actor.Context, msg *pb.DeleteAccountMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+	if err := e.checkPassword(msg.UserId, msg.Password); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	subredditIDs, err := e.store.GetUserSubreddits(msg.UserId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	// The account is kept until every member credited its content, so that
+	// the request can be retried.
+	if _, err := e.askOtherMembers(context, &pb.CreditToDeletedMessage{UserId: msg.UserId}); err != nil {
+		e.fail(context, err)
+		return
+	}
+	for _, subredditID := range subredditIDs {
+		leave := &pb.LeaveSubredditMessage{SubredditId: subredditID, UserId: msg.UserId, Token: msg.Token}
+		if _, err := e.ask(context, SubredditKind, subredditID, leave); err != nil {
+			e.fail(context, err)
+			return
+		}
+	}
+
+	if err := e.store.DeleteUser(msg.UserId); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.AccountDeleted{UserID: msg.UserId})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Account deleted successfully"})
+}
+
+// handleExportUserData answers with everything the store holds about a
+// user, except their password hash.
+func (e *engineCore) handleExportUserData(context actor.Context, msg *pb.ExportUserDataMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+
+	archive := &userArchive{ExportedAt: time.Now().Unix()}
+	user, err := e.store.GetUser(msg.UserId)
+	if err == nil {
+		exported := *user
+		exported.Password = ""
+		archive.User = &exported
+		archive.Subreddits, err = e.store.GetUserSubreddits(msg.UserId)
+	}
+	if err == nil {
+		err = e.storedContent(msg.UserId, archive)
+	}
+	if err == nil {
+		err = e.gatherContent(context, msg.UserId, archive)
+	}
+	if err == nil {
+		archive.MessagesReceived, err = e.store.GetMessages(msg.UserId)
+	}
+	if err == nil {
+		archive.MessagesSent, err = e.store.GetSentMessages(msg.UserId)
+	}
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(archive)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.UserDataExport{UserId: msg.UserId, Archive: data})
+}
+
+// checkPassword confirms a request that needs the user's password on top of
+// their session.
+func (e *engineCore) checkPassword(userID, password string) error {
+	user, err := e.store.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	return auth.CheckPassword(user.Password, password)
+}
@@ -0,0 +1,154 @@
+package actor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+func TestAccountLifecycle(t *testing.T) {
+	engine := newTestEngine(t, memory.NewMemoryStore())
+
+	alice := engine.registerAndLogin("alice")
+	bob := engine.registerAndLogin("bob")
+	engine.createSubreddit(bob, "bob", "s1")
+	engine.succeed(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "bob", Token: bob})
+	engine.succeed(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "alice", Token: alice})
+	engine.succeed(&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Title: "Hello", Token: alice})
+	engine.succeed(&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Content: "first", Token: alice})
+	engine.succeed(&pb.VoteMessage{TargetId: "p1", UserId: "alice", IsUpvote: true, Token: alice})
+	engine.succeed(&pb.DirectMessageMessage{Id: "m1", FromId: "bob", ToId: "alice", Content: "hi", Token: bob})
+	engine.fail(&pb.UserMessage{UserId: "[deleted]", Username: "ghost", Password: "password123"}, pb.ErrorCode_INVALID)
+
+	// Profiles
+	engine.succeed(&pb.UpdateProfileMessage{UserId: "alice", DisplayName: "Alice", Bio: "Gopher", Token: alice})
+	engine.fail(&pb.UpdateProfileMessage{UserId: "alice", Bio: strings.Repeat("x", 201), Token: alice}, pb.ErrorCode_INVALID)
+	engine.fail(&pb.UpdateProfileMessage{UserId: "alice", DisplayName: "Bob", Token: bob}, pb.ErrorCode_FORBIDDEN)
+	profile := engine.request(&pb.GetUserProfileMessage{UserId: "alice"}).(*pb.UserProfileResponse)
+	if profile.DisplayName != "Alice" || profile.Bio != "Gopher" {
+		t.Errorf("Expected the updated profile, got %v", profile)
+	}
+
+	// Changing the password logs out every other session.
+	engine.fail(&pb.ChangePasswordMessage{UserId: "alice", Password: "wrong", NewPassword: "secret456", Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	login, ok := engine.request(&pb.ChangePasswordMessage{UserId: "alice", Password: "password123", NewPassword: "secret456", Token: alice}).(*pb.LoginResponse)
+	if !ok || login.Token == "" {
+		t.Fatalf("Expected a new session, got %v", login)
+	}
+	engine.fail(&pb.UpdateProfileMessage{UserId: "alice", Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.fail(&pb.LoginMessage{UserId: "alice", Password: "password123"}, pb.ErrorCode_UNAUTHENTICATED)
+	alice = login.Token
+
+	// Export
+	export := engine.request(&pb.ExportUserDataMessage{UserId: "alice", Token: alice}).(*pb.UserDataExport)
+	var archive map[string]json.RawMessage
+	if err := json.Unmarshal(export.Archive, &archive); err != nil {
+		t.Fatalf("Expected a JSON archive: %v", err)
+	}
+	for field, want := range map[string]string{
+		"subreddits":        `["s1"]`,
+		"votes":             `{"p1":true}`,
+		"messages_received": `"hi"`,
+		"posts":             `"p1"`,
+		"comments":          `"c1"`,
+	} {
+		if !strings.Contains(string(archive[field]), want) {
+			t.Errorf("Expected %s to contain %s, got %s", field, want, archive[field])
+		}
+	}
+	if strings.Contains(string(archive["user"]), "$2a$") {
+		t.Errorf("Expected no password hash in the archive, got %s", archive["user"])
+	}
+	engine.fail(&pb.ExportUserDataMessage{UserId: "alice", Token: bob}, pb.ErrorCode_FORBIDDEN)
+
+	// Deletion
+	engine.fail(&pb.DeleteAccountMessage{UserId: "alice", Password: "password123", Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.succeed(&pb.DeleteAccountMessage{UserId: "alice", Password: "secret456", Token: alice})
+	engine.fail(&pb.LoginMessage{UserId: "alice", Password: "secret456"}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.fail(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "alice", Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.fail(&pb.PostMessage{Id: "p2", SubredditId: "s1", AuthorId: "alice", Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.fail(&pb.VoteMessage{TargetId: "p1", UserId: "alice", IsUpvote: false, Token: alice}, pb.ErrorCode_UNAUTHENTICATED)
+	engine.fail(&pb.UserMessage{UserId: "alice", Username: "alice", Password: "password123"}, pb.ErrorCode_ALREADY_EXISTS)
+	engine.fail(&pb.DirectMessageMessage{Id: "m2", FromId: "bob", ToId: "alice", Content: "still there?", Token: bob}, pb.ErrorCode_NOT_FOUND)
+	profile = engine.request(&pb.GetUserProfileMessage{UserId: "alice"}).(*pb.UserProfileResponse)
+	if !profile.Deleted || profile.Username != "" || profile.DisplayName != "" {
+		t.Errorf("Expected a cleared, deleted profile, got %v", profile)
+	}
+	feed := engine.request(&pb.GetFeedMessage{SubredditIds: []string{"s1"}}).(*pb.FeedResponse)
+	if len(feed.Posts) != 1 || feed.Posts[0].AuthorId != "[deleted]" {
+		t.Errorf("Expected p1 credited to [deleted], got %v", feed.Posts)
+	}
+	tree := engine.request(&pb.GetCommentsMessage{PostId: "p1"}).(*pb.CommentsResponse)
+	if len(tree.Tree) != 1 || tree.Tree[0].Comment.AuthorId != "[deleted]" || tree.Tree[0].Comment.Content != "first" {
+		t.Errorf("Expected c1 credited to [deleted], got %v", tree.Tree)
+	}
+	members := engine.request(&pb.GetSubredditMembersMessage{SubredditId: "s1"}).(*pb.SubredditMembersResponse)
+	if len(members.UserIds) != 1 || members.UserIds[0] != "bob" {
+		t.Errorf("Expected alice to have left s1, got %v", members.UserIds)
+	}
+
+	got := strings.Join(engine.events.Types(), ",")
+	if !strings.Contains(got, "subreddit_left,account_deleted") {
+		t.Errorf("Expected subreddit_left then account_deleted, got %s", got)
+	}
+}
+
+func TestSessionRevocationsSurviveRestart(t *testing.T) {
+	dir := t.TempDir()
+	tokens := auth.NewTokenManager([]byte("restart-secret"), time.Hour)
+	start := func() (*memory.DurableStore, *actor.RootContext, *actor.PID) {
+		store, err := memory.OpenDurableStore(dir, memory.DurableOptions{})
+		if err != nil {
+			t.Fatalf("Failed to open store: %v", err)
+		}
+		system := actor.NewActorSystem()
+		engine := NewEngineActor(store, metrics.NewRedditMetrics(), WithTokenManager(tokens))
+		return store, system.Root, system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+	}
+	request := func(root *actor.RootContext, pid *actor.PID, msg interface{}) interface{} {
+		t.Helper()
+		result, err := root.RequestFuture(pid, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response for %T: %v", msg, err)
+		}
+		return result
+	}
+
+	store, root, enginePID := start()
+	loggedOut := registerAndLogin(t, root, enginePID, "alice")
+	bob := registerAndLogin(t, root, enginePID, "bob")
+	replaced := request(root, enginePID, &pb.LoginMessage{UserId: "alice", Password: "password123"}).(*pb.LoginResponse).Token
+	if _, ok := request(root, enginePID, &pb.LogoutMessage{Token: loggedOut}).(*pb.SuccessResponse); !ok {
+		t.Fatal("Expected the logout to succeed")
+	}
+	login, ok := request(root, enginePID, &pb.ChangePasswordMessage{
+		UserId: "alice", Password: "password123", NewPassword: "secret456", Token: replaced,
+	}).(*pb.LoginResponse)
+	if !ok {
+		t.Fatalf("Expected a new session, got %v", login)
+	}
+	store.Close()
+
+	// The revocations are stored with alice, so a restarted engine knows them.
+	store, root, enginePID = start()
+	defer store.Close()
+	for name, token := range map[string]string{"logged out": loggedOut, "replaced": replaced} {
+		resp, ok := request(root, enginePID, &pb.SubredditMessage{Id: "s1", Name: "s1", CreatorId: "alice", Token: token}).(*pb.ErrorResponse)
+		if !ok || resp.Code != pb.ErrorCode_UNAUTHENTICATED {
+			t.Errorf("Expected the %s session to be refused after a restart, got %v", name, resp)
+		}
+	}
+	for userID, token := range map[string]string{"alice": login.Token, "bob": bob} {
+		if result, ok := request(root, enginePID, &pb.GetConversationsMessage{UserId: userID, Token: token}).(*pb.ConversationsResponse); !ok {
+			t.Errorf("Expected the live session of %s to be accepted after a restart, got %v", userID, result)
+		}
+	}
+}
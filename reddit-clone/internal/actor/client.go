@@ -3,6 +3,7 @@ package actor
 import (
 	"fmt"
 	protoactor "github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
 	"math/rand"
 	"reddit-clone/api/proto/generated"
 	pb "reddit-clone/api/proto/generated"
@@ -16,7 +17,10 @@ import (
 type ClientActor struct {
 	userID        string
 	username      string
+	password      string
+	token         string
 	enginePID     *protoactor.PID
+	grains        *cluster.Cluster
 	connected     bool
 	subreddits    []string
 	metrics       *metrics.RedditMetrics
@@ -24,92 +28,136 @@ type ClientActor struct {
 	distribution  *common.SimulationDistribution
 	persona       string
 	existingPosts []string
-	postsMutex    sync.RWMutex
+	// postSubreddits maps the posts in existingPosts to their subreddits.
+	postSubreddits map[string]string
+	postsMutex     sync.RWMutex
 }
 
-func NewClientActor(userID string, username string, enginePID *protoactor.PID, behavior *common.ClientBehavior, metrics *metrics.RedditMetrics) *ClientActor {
+// ClientOption customises a ClientActor built by NewClientActor.
+type ClientOption func(*ClientActor)
+
+// WithGrains sends requests straight to the grain that owns them in the given
+// cluster. Requests without a single owner still go to the engine PID.
+func WithGrains(grains *cluster.Cluster) ClientOption {
+	return func(c *ClientActor) {
+		c.grains = grains
+	}
+}
+
+func NewClientActor(userID string, username string, enginePID *protoactor.PID, behavior *common.ClientBehavior, metrics *metrics.RedditMetrics, opts ...ClientOption) *ClientActor {
 	// Generate unique name using timestamp and user ID
 	uniqueName := fmt.Sprintf("user-%s-%d", userID, time.Now().UnixNano())
-	return &ClientActor{
-		userID:        userID,
-		username:      uniqueName,
-		enginePID:     enginePID,
-		connected:     true,
-		subreddits:    make([]string, 0),
-		metrics:       metrics,
-		behavior:      behavior,
-		persona:       behavior.Persona,
-		existingPosts: make([]string, 0),
+	c := &ClientActor{
+		userID:         userID,
+		username:       uniqueName,
+		password:       utils.GenerateID(),
+		enginePID:      enginePID,
+		connected:      true,
+		subreddits:     make([]string, 0),
+		metrics:        metrics,
+		behavior:       behavior,
+		persona:        behavior.Persona,
+		existingPosts:  make([]string, 0),
+		postSubreddits: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
+
+// engine returns the PID that should receive msg.
+func (c *ClientActor) engine(msg interface{}) *protoactor.PID {
+	if c.grains == nil {
+		return c.enginePID
+	}
+	kind, identity, ok := GrainFor(msg)
+	if !ok || identity == "" {
+		return c.enginePID
+	}
+	if pid := c.grains.Get(identity, kind); pid != nil {
+		return pid
+	}
+	return c.enginePID
+}
+
+// recordResult waits for the engine's answer to an action. A rejected action
+// is counted by its error code, not as done.
+func (c *ClientActor) recordResult(future *protoactor.Future, action string) {
+	result, err := future.Result()
+	if err != nil {
+		c.metrics.RecordError()
+		return
+	}
+	if resp, ok := result.(*pb.ErrorResponse); ok {
+		c.metrics.RecordErrorCode(resp.Code.String())
+		return
+	}
+	c.metrics.UpdateActiveUsers(1)
+	c.metrics.RecordAction(c.persona, action)
 }
 
 func (c *ClientActor) Receive(context protoactor.Context) {
 	switch msg := context.Message().(type) {
+	case *protoactor.Started:
+		// Register and log in; both go to the user's actor, which answers
+		// in order, so the session token arrives as a LoginResponse once the
+		// account exists.
+		register := &pb.UserMessage{
+			UserId:   c.userID,
+			Username: c.username,
+			Password: c.password,
+		}
+		context.Request(c.engine(register), register)
+		login := &pb.LoginMessage{
+			UserId:   c.userID,
+			Password: c.password,
+		}
+		context.Request(c.engine(login), login)
+	case *pb.LoginResponse:
+		c.token = msg.Token
 	case *pb.PingMessage:
 		context.Respond(&pb.PongMessage{})
 	case *common.SimulateAction:
 		if c.connected {
 			start := time.Now()
 
-			action := c.performAction(context)
+			// Actions are nil when there is nothing to do, e.g. no known
+			// post to vote on.
+			action := c.performAction()
 			switch actionMsg := action.(interface{}).(type) {
 			case *pb.PostMessage:
-				future := context.RequestFuture(c.enginePID, actionMsg, 5*time.Second)
-				if _, err := future.Result(); err == nil {
-					c.metrics.UpdateActiveUsers(1)
-					c.metrics.RecordAction(c.persona, "post")
-				} else {
-					c.metrics.RecordError()
+				if actionMsg == nil {
+					break
 				}
+				future := context.RequestFuture(c.engine(actionMsg), actionMsg, 5*time.Second)
+				c.recordResult(future, "post")
 
 			case *pb.CommentMessage:
-				future := context.RequestFuture(c.enginePID, actionMsg, 5*time.Second)
-				if _, err := future.Result(); err == nil {
-					c.metrics.UpdateActiveUsers(1)
-					c.metrics.RecordAction(c.persona, "comment")
-				} else {
-					c.metrics.RecordError()
+				if actionMsg == nil {
+					break
 				}
+				future := context.RequestFuture(c.engine(actionMsg), actionMsg, 5*time.Second)
+				c.recordResult(future, "comment")
 
 			case *pb.VoteMessage:
-				future := context.RequestFuture(c.enginePID, actionMsg, 5*time.Second)
-				if _, err := future.Result(); err == nil {
-					c.metrics.UpdateActiveUsers(1)
-					c.metrics.RecordAction(c.persona, "vote")
-				} else {
-					c.metrics.RecordError()
+				if actionMsg == nil {
+					break
+				}
+				future := context.RequestFuture(c.engine(actionMsg), actionMsg, 5*time.Second)
+				c.recordResult(future, "vote")
+
+			case *pb.JoinSubredditMessage:
+				if actionMsg == nil {
+					break
 				}
+				future := context.RequestFuture(c.engine(actionMsg), actionMsg, 5*time.Second)
+				c.recordResult(future, "join")
 			}
 
 			duration := time.Since(start).Seconds()
 			c.metrics.RecordSimulatedAction(duration)
 		}
-	//case *common.SimulateAction:
-	//	if c.connected {
-	//		start := time.Now()
-	//
-	//		// Perform action and record metrics
-	//		action := c.performAction(context)
-	//		//if action != nil {
-	//		// Send action to engine and wait for response
-	//
-	//		switch actionMsg := action.(interface{}).(type) {
-	//		case *generated.PostMessage,
-	//			*generated.CommentMessage,
-	//			*generated.VoteMessage,
-	//			*generated.JoinSubredditMessage:
-	//
-	//			future := context.RequestFuture(c.enginePID, action, 5*time.Second)
-	//			if _, err := future.Result(); err == nil {
-	//				// Update metrics based on successful action
-	//				c.metrics.UpdateActiveUsers(1)
-	//				duration := time.Since(start).Seconds()
-	//				c.metrics.RecordSimulatedAction(duration)
-	//			} else {
-	//				c.metrics.RecordError()
-	//			}
-	//		}
-	//	}
 
 	case *common.ConnectionStatus:
 		wasConnected := c.connected
@@ -124,73 +172,27 @@ func (c *ClientActor) Receive(context protoactor.Context) {
 	}
 }
 
-//	// Send action to engine
-//	response, err := context.RequestFuture(c.enginePID, action, 5*time.Second).Result()
-//	if err != nil {
-//		c.metrics.RecordError()
-//		return
-//	}
-//
-//	// Record timing and metrics
-//	duration := time.Since(start).Seconds()
-//	c.metrics.RecordSimulatedAction(duration)
-//	c.metrics.RecordAction(c.persona, msg.ActionType)
-//
-//	// Update metrics based on response
-//	if resp, ok := response.(*common.ActionResponse); ok {
-//		c.metrics.UpdateActiveUsers(float64(resp.ActiveUsers))
-//	}
-//}
-
-//	case *common.ConnectionStatus:
-//		c.connected = msg.Connected
-//		if c.connected {
-//			c.metrics.UpdateActiveUsers(1)
-//		} else {
-//			c.metrics.UpdateActiveUsers(-1)
-//		}
-//	}
-//}
-
-func (c *ClientActor) performAction(context protoactor.Context) interface{} {
-	//start := time.Now()
-
+func (c *ClientActor) performAction() interface{} {
 	if !c.isActiveHour(time.Now().Hour()) {
 		return &pb.EmptyMessage{}
 	}
-	//var actionType common.ActionType
 	rand := rand.Float64()
 	var action interface{}
 	switch {
 	case rand < c.behavior.PostProbability:
-
-		//c.metrics.RecordAction(c.persona, "post")
-		//return c.createPost(context)
-		action = c.createPost(context)
+		action = c.createPost()
 	case rand < c.behavior.PostProbability+c.behavior.CommentProbability:
-
-		//c.metrics.RecordAction(c.persona, "comment")
-		//return c.createComment(context)
-		action = c.createComment(context)
+		action = c.createComment()
 	case rand < c.behavior.PostProbability+c.behavior.CommentProbability+c.behavior.VoteProbability:
-
-		//c.metrics.RecordAction(c.persona, "vote")
-		//return c.vote(context)
-		action = c.vote(context)
+		action = c.vote()
 	default:
-		action = c.joinSubreddit(context)
-
-		//c.metrics.RecordAction(c.persona, "join")
-		//return c.joinSubreddit(context)
+		action = c.joinSubreddit()
 	}
 	c.metrics.UpdateActiveUsers(1)
 	return action
 }
 
-// c.metrics.RecordSimulatedAction(time.Since(start).Seconds())
-// }
-// made change here
-func (c *ClientActor) createPost(context protoactor.Context) *generated.PostMessage {
+func (c *ClientActor) createPost() *generated.PostMessage {
 	if len(c.subreddits) == 0 {
 		return nil
 	}
@@ -217,31 +219,34 @@ func (c *ClientActor) createPost(context protoactor.Context) *generated.PostMess
 		Content:     content,
 		CreatedAt:   time.Now().Unix(),
 		IsRepost:    isRepost,
+		Token:       c.token,
 	}
-	c.addExistingPost(post.Id)
-	context.Request(c.enginePID, post)
+	c.addExistingPost(post.Id, post.SubredditId)
 	return post
 }
 
-func (c *ClientActor) createComment(context protoactor.Context) *pb.CommentMessage {
-	if len(c.subreddits) == 0 {
+func (c *ClientActor) createComment() *pb.CommentMessage {
+	// The engine rejects comments on posts that do not exist.
+	postID, subredditID := c.getRandomKnownPost()
+	if postID == "" {
 		return nil
 	}
 
 	comment := &pb.CommentMessage{
 		Id:        utils.GenerateID(),
-		PostId:    utils.GenerateID(), // In real implementation, get actual post ID
-		ParentId:  "",                 // Root level comment
+		PostId:    postID,
+		ParentId:  "", // Root level comment
 		AuthorId:  c.userID,
 		Content:   utils.GenerateRandomContent(),
 		CreatedAt: time.Now().Unix(),
+		Token:     c.token,
+		// Lets a clustered engine route the comment without a lookup.
+		SubredditId: subredditID,
 	}
-
-	context.Request(c.enginePID, comment)
 	return comment
 }
 
-func (c *ClientActor) joinSubreddit(context protoactor.Context) *pb.JoinSubredditMessage {
+func (c *ClientActor) joinSubreddit() *pb.JoinSubredditMessage {
 	if len(c.distribution.GetSubreddits()) == 0 {
 		return nil
 	}
@@ -254,20 +259,24 @@ func (c *ClientActor) joinSubreddit(context protoactor.Context) *pb.JoinSubreddi
 	join := &pb.JoinSubredditMessage{
 		SubredditId: subredditID,
 		UserId:      c.userID,
+		Token:       c.token,
 	}
-
-	context.Request(c.enginePID, join)
 	return join
 }
 
-func (c *ClientActor) vote(context protoactor.Context) *pb.VoteMessage {
-	vote := &pb.VoteMessage{
-		TargetId: utils.GenerateID(), // In real implementation, get actual post/comment ID
-		UserId:   c.userID,
-		IsUpvote: rand.Float32() > 0.3, // 70% chance of upvote
+func (c *ClientActor) vote() *pb.VoteMessage {
+	postID, subredditID := c.getRandomKnownPost()
+	if postID == "" {
+		return nil
 	}
 
-	context.Request(c.enginePID, vote)
+	vote := &pb.VoteMessage{
+		TargetId:    postID,
+		SubredditId: subredditID,
+		UserId:      c.userID,
+		IsUpvote:    rand.Float32() > 0.3, // 70% chance of upvote
+		Token:       c.token,
+	}
 	return vote
 }
 
@@ -295,10 +304,11 @@ func getActionType(action interface{}) string {
 	}
 }
 
-func (c *ClientActor) addExistingPost(postID string) {
+func (c *ClientActor) addExistingPost(postID, subredditID string) {
 	c.postsMutex.Lock()
 	defer c.postsMutex.Unlock()
 	c.existingPosts = append(c.existingPosts, postID)
+	c.postSubreddits[postID] = subredditID
 }
 
 func (c *ClientActor) getRandomExistingPost() string {
@@ -312,3 +322,16 @@ func (c *ClientActor) getRandomExistingPost() string {
 	randomIndex := rand.Intn(len(c.existingPosts))
 	return c.existingPosts[randomIndex]
 }
+
+// getRandomKnownPost returns a post this client created and its subreddit,
+// or empty strings if it has created none.
+func (c *ClientActor) getRandomKnownPost() (postID, subredditID string) {
+	c.postsMutex.RLock()
+	defer c.postsMutex.RUnlock()
+
+	if len(c.existingPosts) == 0 {
+		return "", ""
+	}
+	postID = c.existingPosts[rand.Intn(len(c.existingPosts))]
+	return postID, c.postSubreddits[postID]
+}
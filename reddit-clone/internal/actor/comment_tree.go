@@ -0,0 +1,170 @@
+package actor
+
+import (
+	"sort"
+	"time"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/ranking"
+	"reddit-clone/internal/store"
+)
+
+// Comment sort orders accepted by GetCommentsMessage.Sort.
+const (
+	CommentSortTop           = "top"
+	CommentSortNew           = "new"
+	CommentSortControversial = "controversial"
+)
+
+// commentOrder sorts siblings by a per-sort score, highest first. Ties fall
+// back to creation time and then ID so that pages are stable between requests.
+var commentOrder = pagination.Order{ScoreDescending: true}
+
+// commentTreeOptions controls how much of a thread buildCommentTree returns.
+type commentTreeOptions struct {
+	maxDepth int    // reply levels to expand below the first level; 0 means unlimited
+	limit    int    // replies per comment; 0 means unlimited
+	parentID string // expand the replies of this comment instead of the post
+	page     pagination.Request
+	key      func(*models.Comment) pagination.Key
+}
+
+func newCommentTreeOptions(msg *pb.GetCommentsMessage) (commentTreeOptions, error) {
+	if msg.MaxDepth < 0 || msg.Limit < 0 || msg.ReplyLimit < 0 {
+		return commentTreeOptions{}, invalidf("max_depth, limit and reply_limit must not be negative")
+	}
+	key, err := commentKey(msg.Sort)
+	if err != nil {
+		return commentTreeOptions{}, err
+	}
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		return commentTreeOptions{}, err
+	}
+	return commentTreeOptions{
+		maxDepth: int(msg.MaxDepth),
+		limit:    int(msg.ReplyLimit),
+		parentID: msg.ParentId,
+		page:     page,
+		key:      key,
+	}, nil
+}
+
+// commentKey returns the sort key function for a sort name.
+func commentKey(name string) (func(*models.Comment) pagination.Key, error) {
+	var score func(*models.Comment) float64
+	switch name {
+	case "", CommentSortTop:
+		score = func(c *models.Comment) float64 { return float64(c.Karma) }
+	case CommentSortNew:
+		score = func(c *models.Comment) float64 { return float64(c.Created) }
+	case CommentSortControversial:
+		score = func(c *models.Comment) float64 { return ranking.Controversy(c.Ups, c.Downs) }
+	default:
+		return nil, invalidf("unknown comment sort %q", name)
+	}
+
+	return func(c *models.Comment) pagination.Key {
+		return pagination.Key{Score: score(c), Time: c.Created, ID: c.ID}
+	}, nil
+}
+
+// buildCommentTree nests the comments of a post under their parents. Replies
+// whose parent is missing are treated as first-level comments so that they
+// are never silently dropped. The first level, either the post's comments or
+// the replies of opts.parentID, is paginated with opts.page and has depth 0;
+// replies are expanded down to depth opts.maxDepth and cut at opts.limit.
+func buildCommentTree(comments []*models.Comment, opts commentTreeOptions) (pagination.Page[*pb.CommentNode], error) {
+	byID := make(map[string]*models.Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	replies := make(map[string][]*models.Comment)
+	for _, comment := range comments {
+		parent := comment.ParentID
+		if _, exists := byID[parent]; !exists {
+			parent = ""
+		}
+		replies[parent] = append(replies[parent], comment)
+	}
+	for _, siblings := range replies {
+		sort.Slice(siblings, func(i, j int) bool {
+			return commentOrder.Compare(opts.key(siblings[i]), opts.key(siblings[j])) < 0
+		})
+	}
+
+	if opts.parentID != "" {
+		if _, exists := byID[opts.parentID]; !exists {
+			return pagination.Page[*pb.CommentNode]{}, &store.NotFoundError{Kind: "comment", ID: opts.parentID}
+		}
+	}
+
+	var nodeFor func(comment *models.Comment, depth int) *pb.CommentNode
+	nodeFor = func(comment *models.Comment, depth int) *pb.CommentNode {
+		node := &pb.CommentNode{
+			Comment: commentToProto(comment),
+			Depth:   int32(depth),
+		}
+		children := replies[comment.ID]
+		if opts.maxDepth > 0 && depth >= opts.maxDepth {
+			node.MoreReplies = int32(len(children))
+			return node
+		}
+		if opts.limit > 0 && len(children) > opts.limit {
+			node.MoreReplies = int32(len(children) - opts.limit)
+			children = children[:opts.limit]
+		}
+		node.Replies = make([]*pb.CommentNode, 0, len(children))
+		for _, child := range children {
+			node.Replies = append(node.Replies, nodeFor(child, depth+1))
+		}
+		return node
+	}
+
+	first := pagination.Paginate(replies[opts.parentID], opts.key, commentOrder, opts.page, time.Time{})
+	page := pagination.Page[*pb.CommentNode]{
+		Items:     make([]*pb.CommentNode, 0, len(first.Items)),
+		Next:      first.Next,
+		Prev:      first.Prev,
+		Remaining: first.Remaining,
+	}
+	for _, comment := range first.Items {
+		page.Items = append(page.Items, nodeFor(comment, 0))
+	}
+	return page, nil
+}
+
+// flattenCommentTree lists the comments of a tree in depth-first order.
+func flattenCommentTree(nodes []*pb.CommentNode) []*pb.CommentMessage {
+	var flat []*pb.CommentMessage
+	for _, node := range nodes {
+		flat = append(flat, node.Comment)
+		flat = append(flat, flattenCommentTree(node.Replies)...)
+	}
+	return flat
+}
+
+func commentToProto(comment *models.Comment) *pb.CommentMessage {
+	msg := &pb.CommentMessage{
+		Id:        comment.ID,
+		PostId:    comment.PostID,
+		ParentId:  comment.ParentID,
+		AuthorId:  comment.AuthorID,
+		Content:   comment.Content,
+		CreatedAt: comment.Created,
+		Score:     comment.Karma,
+		EditedAt:  comment.Edited,
+	}
+	// Removed and deleted comments keep their place so that their replies
+	// stay visible.
+	switch {
+	case comment.Removed:
+		msg.AuthorId, msg.Content, msg.Removed = "", "", true
+	case comment.Deleted:
+		msg.AuthorId, msg.Content, msg.Deleted = deletedText, deletedText, true
+	}
+	return msg
+}
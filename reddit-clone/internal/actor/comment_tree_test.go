@@ -0,0 +1,169 @@
+package actor
+
+import (
+	"testing"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+)
+
+// testThread is:
+//
+//	c1 (karma 5)
+//	  c3
+//	    c5
+//	c2 (karma 9)
+//	  c4
+//	c6 (reply to a deleted comment)
+func testThread() []*models.Comment {
+	return []*models.Comment{
+		{ID: "c5", PostID: "p1", ParentID: "c3", Created: 5},
+		{ID: "c4", PostID: "p1", ParentID: "c2", Created: 4},
+		{ID: "c3", PostID: "p1", ParentID: "c1", Created: 3},
+		{ID: "c1", PostID: "p1", Karma: 5, Ups: 10, Downs: 5, Created: 1},
+		{ID: "c2", PostID: "p1", Karma: 9, Ups: 9, Created: 2},
+		{ID: "c6", PostID: "p1", ParentID: "gone", Created: 6},
+	}
+}
+
+func buildTestTree(t *testing.T, msg *pb.GetCommentsMessage) pagination.Page[*pb.CommentNode] {
+	t.Helper()
+	opts, err := newCommentTreeOptions(msg)
+	if err != nil {
+		t.Fatalf("Failed to parse options: %v", err)
+	}
+	page, err := buildCommentTree(testThread(), opts)
+	if err != nil {
+		t.Fatalf("Failed to build comment tree: %v", err)
+	}
+	return page
+}
+
+func commentIDs(comments []*pb.CommentMessage) []string {
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.Id)
+	}
+	return ids
+}
+
+func TestBuildCommentTreeKeepsEveryReply(t *testing.T) {
+	tree := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1"}).Items
+
+	got := commentIDs(flattenCommentTree(tree))
+	want := []string{"c2", "c4", "c1", "c3", "c5", "c6"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+
+	c5 := tree[1].Replies[0].Replies[0]
+	if c5.Comment.Id != "c5" || c5.Depth != 2 {
+		t.Errorf("Expected c5 at depth 2, got %s at depth %d", c5.Comment.Id, c5.Depth)
+	}
+}
+
+func TestBuildCommentTreeSortOrders(t *testing.T) {
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"c2", "c1", "c6"}},
+		{CommentSortTop, []string{"c2", "c1", "c6"}},
+		{CommentSortNew, []string{"c6", "c2", "c1"}},
+		{CommentSortControversial, []string{"c1", "c2", "c6"}},
+	}
+	for _, tt := range tests {
+		tree := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", Sort: tt.sort, MaxDepth: 1})
+		for i, node := range tree.Items {
+			if node.Comment.Id != tt.want[i] {
+				t.Errorf("sort %q: expected %v, got %s at %d", tt.sort, tt.want, node.Comment.Id, i)
+			}
+		}
+	}
+
+	if _, err := newCommentTreeOptions(&pb.GetCommentsMessage{Sort: "random"}); err == nil {
+		t.Error("Expected an error for an unknown sort order")
+	}
+}
+
+func TestBuildCommentTreeDepthAndLoadMore(t *testing.T) {
+	tree := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", MaxDepth: 1}).Items
+	c1 := tree[1]
+	if len(c1.Replies) != 1 || c1.Replies[0].Comment.Id != "c3" || c1.MoreReplies != 0 {
+		t.Fatalf("Expected max_depth 1 to expand c1's reply c3, got %v", commentIDs(flattenCommentTree([]*pb.CommentNode{c1})))
+	}
+	c3 := c1.Replies[0]
+	if len(c3.Replies) != 0 || c3.MoreReplies != 1 {
+		t.Errorf("Expected c3 to report 1 unexpanded reply, got %d replies and %d more", len(c3.Replies), c3.MoreReplies)
+	}
+
+	tree = buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", MaxDepth: 2}).Items
+	if c5 := tree[1].Replies[0].Replies; len(c5) != 1 || c5[0].Comment.Id != "c5" {
+		t.Errorf("Expected max_depth 2 to expand c3's reply c5, got %v", commentIDs(flattenCommentTree(c5)))
+	}
+
+	tree = buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", ParentId: "c3"}).Items
+	if len(tree) != 1 || tree[0].Comment.Id != "c5" {
+		t.Errorf("Expected loading more under c3 to return c5, got %v", commentIDs(flattenCommentTree(tree)))
+	}
+
+	opts, _ := newCommentTreeOptions(&pb.GetCommentsMessage{ParentId: "missing"})
+	if _, err := buildCommentTree(testThread(), opts); err == nil {
+		t.Error("Expected an error when loading more under an unknown comment")
+	}
+}
+
+func TestBuildCommentTreeCursors(t *testing.T) {
+	first := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", Limit: 1, MaxDepth: 1})
+	if len(first.Items) != 1 || first.Items[0].Comment.Id != "c2" || first.Remaining != 2 || first.Prev != "" {
+		t.Fatalf("Expected the first page to hold c2 with 2 more, got %v", commentIDs(flattenCommentTree(first.Items)))
+	}
+
+	second := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", Limit: 1, MaxDepth: 1, After: first.Next})
+	if len(second.Items) != 1 || second.Items[0].Comment.Id != "c1" || second.Remaining != 1 {
+		t.Fatalf("Expected the second page to hold c1 with 1 more, got %v", commentIDs(flattenCommentTree(second.Items)))
+	}
+
+	back := buildTestTree(t, &pb.GetCommentsMessage{PostId: "p1", Limit: 1, MaxDepth: 1, Before: second.Prev})
+	if len(back.Items) != 1 || back.Items[0].Comment.Id != "c2" {
+		t.Errorf("Expected paging back to return c2, got %v", commentIDs(flattenCommentTree(back.Items)))
+	}
+
+	// Replies are not cut by the page size.
+	if c4 := first.Items[0].Replies; len(c4) != 1 || c4[0].Comment.Id != "c4" {
+		t.Errorf("Expected c2's reply on the first page, got %v", commentIDs(flattenCommentTree(c4)))
+	}
+
+	if _, err := newCommentTreeOptions(&pb.GetCommentsMessage{After: "not-a-cursor"}); err == nil {
+		t.Error("Expected an error for a malformed cursor")
+	}
+}
+
+func TestBuildCommentTreeReplyLimit(t *testing.T) {
+	comments := append(testThread(), &models.Comment{ID: "c7", PostID: "p1", ParentID: "c2", Karma: 1, Created: 7})
+	opts, err := newCommentTreeOptions(&pb.GetCommentsMessage{PostId: "p1", Limit: 1, ReplyLimit: 1})
+	if err != nil {
+		t.Fatalf("Failed to parse options: %v", err)
+	}
+	page, err := buildCommentTree(comments, opts)
+	if err != nil {
+		t.Fatalf("Failed to build comment tree: %v", err)
+	}
+	if len(page.Items) != 1 || page.Remaining != 2 {
+		t.Fatalf("Expected a page of 1 comment with 2 more, got %v", commentIDs(flattenCommentTree(page.Items)))
+	}
+	c2 := page.Items[0]
+	if len(c2.Replies) != 1 || c2.Replies[0].Comment.Id != "c7" || c2.MoreReplies != 1 {
+		t.Errorf("Expected c2 to show c7 and 1 more reply, got %v and %d more", commentIDs(flattenCommentTree(c2.Replies)), c2.MoreReplies)
+	}
+
+	if _, err := newCommentTreeOptions(&pb.GetCommentsMessage{ReplyLimit: -1}); err == nil {
+		t.Error("Expected an error for a negative reply_limit")
+	}
+}
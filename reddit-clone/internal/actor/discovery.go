@@ -0,0 +1,274 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/ranking"
+	"reddit-clone/internal/store"
+)
+
+// Subreddit listing orders.
+const (
+	SortMembers = "members"
+	SortActive  = "active"
+	SortNew     = "new"
+)
+
+// defaultTrendingWindow is the window of a trending request that names none.
+const defaultTrendingWindow = 24 * time.Hour
+
+// lastActivity returns when the newest live post or comment of a subreddit
+// was created, or 0 if it has none.
+func (e *engineCore) lastActivity(subredditID string) (int64, error) {
+	_, last, err := e.store.GetSubredditActivity(subredditID, math.MaxInt64)
+	return last, err
+}
+
+func subredditInfo(subreddit *models.Subreddit, lastActivity int64) *pb.SubredditInfo {
+	return &pb.SubredditInfo{
+		Id:             subreddit.ID,
+		Name:           subreddit.Name,
+		Description:    subreddit.Description,
+		CreatorId:      subreddit.CreatorID,
+		CreatedAt:      subreddit.Created,
+		Members:        int32(len(subreddit.Members)),
+		LastActivityAt: lastActivity,
+	}
+}
+
+func (e *engineCore) handleGetSubreddit(context actor.Context, msg *pb.GetSubredditMessage) {
+	start := time.Now()
+
+	var subreddit *models.Subreddit
+	var err error
+	switch {
+	case msg.Id != "":
+		subreddit, err = e.store.GetSubreddit(msg.Id)
+	case msg.Name != "":
+		subreddit, err = e.store.GetSubredditByName(msg.Name)
+	default:
+		err = invalidf("id or name is required")
+	}
+	if errors.Is(err, store.ErrNotFound) && msg.Id == "" {
+		// In cluster mode another member may store it.
+		if info := e.findSubreddit(context, msg.Name); info != nil {
+			e.metrics.RecordRequest(time.Since(start).Seconds())
+			context.Respond(info)
+			return
+		}
+	}
+	var last int64
+	if err == nil {
+		last, err = e.lastActivity(subreddit.ID)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(subredditInfo(subreddit, last))
+}
+
+// findSubreddit asks the other cluster members for the subreddit called
+// name, returning nil if none stores it.
+func (e *engineCore) findSubreddit(context actor.Context, name string) *pb.SubredditInfo {
+	answers, err := askAll(context, e.otherMembers(), func(int) interface{} {
+		return &pb.GetSubredditMessage{Name: name}
+	})
+	if err != nil {
+		return nil
+	}
+	for _, answer := range answers {
+		if info, ok := answer.(*pb.SubredditInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// subredditListing is a subreddit in a listing. The last activity of those
+// in this member's store is looked up only when they are sorted by it or
+// listed.
+type subredditListing struct {
+	info   *pb.SubredditInfo
+	stored bool
+}
+
+// subredditOrders give the sort key and order of every listing order. Ties
+// fall back to the subreddit ID.
+var subredditOrders = map[string]struct {
+	key   func(subredditListing) pagination.Key
+	order pagination.Order
+}{
+	SortMembers: {
+		key: func(l subredditListing) pagination.Key {
+			return pagination.Key{Score: float64(l.info.Members), ID: l.info.Id}
+		},
+		order: pagination.Order{ScoreDescending: true},
+	},
+	SortActive: {
+		key: func(l subredditListing) pagination.Key {
+			return pagination.Key{Time: l.info.LastActivityAt, ID: l.info.Id}
+		},
+		order: pagination.Order{TimeDescending: true},
+	},
+	SortNew: {
+		key: func(l subredditListing) pagination.Key {
+			return pagination.Key{Time: l.info.CreatedAt, ID: l.info.Id}
+		},
+		order: pagination.Order{TimeDescending: true},
+	},
+}
+
+func (e *engineCore) handleListSubreddits(context actor.Context, msg *pb.ListSubredditsMessage) {
+	start := time.Now()
+
+	name := msg.Sort
+	if name == "" {
+		name = SortMembers
+	}
+	sorting, known := subredditOrders[name]
+	if !known {
+		e.fail(context, invalidf("unknown subreddit sort %q", msg.Sort))
+		return
+	}
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	subreddits, err := e.store.ListSubreddits()
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	listings := make([]subredditListing, 0, len(subreddits))
+	for _, subreddit := range subreddits {
+		listing := subredditListing{info: subredditInfo(subreddit, 0), stored: true}
+		// The other orders only need the activity of the returned page.
+		if name == SortActive {
+			if listing.info.LastActivityAt, err = e.lastActivity(subreddit.ID); err != nil {
+				break
+			}
+		}
+		listings = append(listings, listing)
+	}
+	var answers []interface{}
+	if err == nil {
+		answers, err = e.askOtherMembers(context, &pb.ListSubredditsMessage{Sort: name})
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	for _, answer := range answers {
+		listed, ok := answer.(*pb.SubredditsResponse)
+		if !ok {
+			e.fail(context, fmt.Errorf("unexpected answer %T", answer))
+			return
+		}
+		for _, info := range listed.Subreddits {
+			listings = append(listings, subredditListing{info: info})
+		}
+	}
+	sort.Slice(listings, func(i, j int) bool {
+		return sorting.order.Compare(sorting.key(listings[i]), sorting.key(listings[j])) < 0
+	})
+	listed := pagination.Paginate(listings, sorting.key, sorting.order, page, time.Time{})
+
+	response := &pb.SubredditsResponse{
+		Subreddits: make([]*pb.SubredditInfo, 0, len(listed.Items)),
+		NextCursor: listed.Next,
+		PrevCursor: listed.Prev,
+	}
+	for _, listing := range listed.Items {
+		if listing.stored && name != SortActive {
+			if listing.info.LastActivityAt, err = e.lastActivity(listing.info.Id); err != nil {
+				e.fail(context, err)
+				return
+			}
+		}
+		response.Subreddits = append(response.Subreddits, listing.info)
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
+
+// handleGetTrendingSubreddits ranks subreddits by how fast posts and comments
+// arrived within the window, most members first among equals.
+func (e *engineCore) handleGetTrendingSubreddits(context actor.Context, msg *pb.GetTrendingSubredditsMessage) {
+	start := time.Now()
+
+	window, err := ranking.ParseWindow(msg.Window)
+	if err == nil && msg.Window == "" {
+		window = defaultTrendingWindow
+	}
+	if err == nil && window == 0 {
+		err = invalidf("trending needs a time window")
+	}
+	var subreddits []*models.Subreddit
+	if err == nil {
+		subreddits, err = e.store.ListSubreddits()
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	since := e.now().Add(-window).Unix()
+	var trending []*pb.SubredditInfo
+	for _, subreddit := range subreddits {
+		recent, last, err := e.store.GetSubredditActivity(subreddit.ID, since)
+		if err != nil {
+			e.fail(context, err)
+			return
+		}
+		if recent == 0 {
+			continue
+		}
+		info := subredditInfo(subreddit, last)
+		info.Velocity = float64(recent) / window.Hours()
+		trending = append(trending, info)
+	}
+	answers, err := e.askOtherMembers(context, &pb.GetTrendingSubredditsMessage{Window: msg.Window})
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	for _, answer := range answers {
+		found, ok := answer.(*pb.SubredditsResponse)
+		if !ok {
+			e.fail(context, fmt.Errorf("unexpected answer %T", answer))
+			return
+		}
+		trending = append(trending, found.Subreddits...)
+	}
+	sort.Slice(trending, func(i, j int) bool {
+		a, b := trending[i], trending[j]
+		if a.Velocity != b.Velocity {
+			return a.Velocity > b.Velocity
+		}
+		if a.Members != b.Members {
+			return a.Members > b.Members
+		}
+		return a.Id < b.Id
+	})
+	if msg.Limit > 0 && len(trending) > int(msg.Limit) {
+		trending = trending[:msg.Limit]
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SubredditsResponse{Subreddits: trending})
+}
@@ -0,0 +1,78 @@
+package actor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store/memory"
+)
+
+func TestSubredditDiscovery(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	hoursAgo := func(h int64) int64 { return now.Unix() - h*3600 }
+
+	store := memory.NewMemoryStore()
+	for _, subreddit := range []*models.Subreddit{
+		{ID: "go", Name: "golang", Created: hoursAgo(300), Members: map[string]bool{"u1": true, "u2": true, "u3": true}},
+		{ID: "rs", Name: "rust", Created: hoursAgo(200), Members: map[string]bool{"u1": true}},
+		{ID: "py", Name: "Python", Created: hoursAgo(100), Members: map[string]bool{"u1": true, "u2": true}},
+	} {
+		store.CreateSubreddit(subreddit)
+	}
+	// golang is big but quiet; rust had a busy hour; python a slow day.
+	store.CreatePost(&models.Post{ID: "g1", SubredditID: "go", Created: hoursAgo(48)})
+	store.CreatePost(&models.Post{ID: "r1", SubredditID: "rs", Created: hoursAgo(1)})
+	store.AddComment(&models.Comment{ID: "rc1", PostID: "r1", Created: now.Unix()})
+	store.AddComment(&models.Comment{ID: "rc2", PostID: "r1", Created: now.Unix()})
+	store.CreatePost(&models.Post{ID: "p1", SubredditID: "py", Created: hoursAgo(20)})
+	store.CreatePost(&models.Post{ID: "p2", SubredditID: "py", Created: hoursAgo(10), Removed: true})
+
+	engine := newTestEngine(t, store, WithClock(func() time.Time { return now }))
+
+	ids := func(msg interface{}) []string {
+		t.Helper()
+		response, ok := engine.request(msg).(*pb.SubredditsResponse)
+		if !ok {
+			t.Fatalf("Expected SubredditsResponse for %v", msg)
+		}
+		var ids []string
+		for _, subreddit := range response.Subreddits {
+			ids = append(ids, subreddit.Id)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		msg  interface{}
+		want string
+	}{
+		{&pb.ListSubredditsMessage{}, "go,py,rs"},
+		{&pb.ListSubredditsMessage{Sort: "active"}, "rs,py,go"},
+		{&pb.ListSubredditsMessage{Sort: "new", Limit: 2}, "py,rs"},
+		{&pb.GetTrendingSubredditsMessage{}, "rs,py"},
+		{&pb.GetTrendingSubredditsMessage{Window: "week"}, "rs,go,py"},
+		{&pb.GetTrendingSubredditsMessage{Window: "hour", Limit: 1}, "rs"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(ids(tt.msg), ","); got != tt.want {
+			t.Errorf("%v: expected %s, got %s", tt.msg, tt.want, got)
+		}
+	}
+
+	info, ok := engine.request(&pb.GetSubredditMessage{Name: "PYTHON"}).(*pb.SubredditInfo)
+	if !ok || info.Id != "py" || info.Members != 2 || info.LastActivityAt != hoursAgo(20) {
+		t.Errorf("Expected python with 2 members active 20 hours ago, got %v", info)
+	}
+	for _, msg := range []interface{}{
+		&pb.GetSubredditMessage{Name: "missing"},
+		&pb.ListSubredditsMessage{Sort: "alphabetical"},
+		&pb.GetTrendingSubredditsMessage{Window: "all"},
+	} {
+		if _, ok := engine.request(msg).(*pb.ErrorResponse); !ok {
+			t.Errorf("Expected ErrorResponse for %v", msg)
+		}
+	}
+}
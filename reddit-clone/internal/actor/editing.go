@@ -0,0 +1,206 @@
+package actor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// Edits and deletions are routed to the subreddit of the content like the
+// votes and removals they may race with. Only the author may edit or delete
+// a post or comment; moderators remove content instead (see moderation.go).
+
+// deletedText replaces the author and content of a deleted comment.
+const deletedText = "[deleted]"
+
+// checkAuthor requires userID to have written the post or comment id.
+func checkAuthor(kind, id, authorID, userID string) error {
+	if authorID != userID {
+		return forbiddenf("%s %s was not written by user %s", kind, id, userID)
+	}
+	return nil
+}
+
+// checkEditable requires content to be neither deleted nor removed.
+func checkEditable(kind, id string, deleted, removed bool) error {
+	switch {
+	case deleted:
+		return forbiddenf("%s %s is deleted", kind, id)
+	case removed:
+		return forbiddenf("%s %s is removed", kind, id)
+	}
+	return nil
+}
+
+// checkContent requires an edit to leave some text; deleting is how an author
+// takes the text back.
+func checkContent(kind, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return invalidf("%s content must not be empty", kind)
+	}
+	return nil
+}
+
+func (e *engineCore) handleEditPost(context actor.Context, msg *pb.EditPostMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
+
+	post, err := e.store.GetPost(msg.PostId)
+	if err == nil {
+		err = checkAuthor("post", post.ID, post.AuthorID, msg.AuthorId)
+	}
+	if err == nil {
+		err = checkEditable("post", post.ID, post.Deleted, post.Removed)
+	}
+	if err == nil {
+		err = checkContent("post", msg.Content)
+	}
+	if err == nil {
+		err = e.store.EditPost(post.ID, msg.Content, time.Now().Unix())
+	}
+	if err == nil {
+		post, err = e.store.GetPost(post.ID)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.PostEdited{Post: post})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Post edited successfully"})
+}
+
+func (e *engineCore) handleEditComment(context actor.Context, msg *pb.EditCommentMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
+
+	comment, err := e.store.GetComment(msg.CommentId)
+	if err == nil {
+		err = checkAuthor("comment", comment.ID, comment.AuthorID, msg.AuthorId)
+	}
+	if err == nil {
+		err = checkEditable("comment", comment.ID, comment.Deleted, comment.Removed)
+	}
+	if err == nil {
+		err = checkContent("comment", msg.Content)
+	}
+	if err == nil {
+		err = e.store.EditComment(comment.ID, msg.Content, time.Now().Unix())
+	}
+	if err == nil {
+		comment, err = e.store.GetComment(comment.ID)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.CommentEdited{Comment: comment, SubredditID: e.postSubreddit(comment.PostID)})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Comment edited successfully"})
+}
+
+// handleDeletePost deletes a post. Deleting it twice is the same as deleting
+// it once.
+func (e *engineCore) handleDeletePost(context actor.Context, msg *pb.DeletePostMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
+
+	post, err := e.store.GetPost(msg.PostId)
+	if err == nil {
+		err = checkAuthor("post", post.ID, post.AuthorID, msg.AuthorId)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	if post.Deleted {
+		e.metrics.RecordRequest(time.Since(start).Seconds())
+		context.Respond(&pb.SuccessResponse{Message: "Post already deleted"})
+		return
+	}
+	if err := e.store.DeletePost(post.ID); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.PostDeleted{PostID: post.ID, SubredditID: post.SubredditID, AuthorID: post.AuthorID})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Post deleted successfully"})
+}
+
+// handleDeleteComment deletes a comment. Its replies stay in the comment
+// tree under a "[deleted]" placeholder.
+func (e *engineCore) handleDeleteComment(context actor.Context, msg *pb.DeleteCommentMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
+
+	comment, err := e.store.GetComment(msg.CommentId)
+	if err == nil {
+		err = checkAuthor("comment", comment.ID, comment.AuthorID, msg.AuthorId)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	if comment.Deleted {
+		e.metrics.RecordRequest(time.Since(start).Seconds())
+		context.Respond(&pb.SuccessResponse{Message: "Comment already deleted"})
+		return
+	}
+	if err := e.store.DeleteComment(comment.ID); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.CommentDeleted{CommentID: comment.ID, PostID: comment.PostID, AuthorID: comment.AuthorID})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Comment deleted successfully"})
+}
+
+// handleGetEditHistory lists every version of a post or comment. Deleted and
+// removed content has no visible history.
+func (e *engineCore) handleGetEditHistory(context actor.Context, msg *pb.GetEditHistoryMessage) {
+	start := time.Now()
+
+	var history []models.Revision
+	var current models.Revision
+	hidden := false
+	if post, err := e.store.GetPost(msg.TargetId); err == nil {
+		history, hidden = post.History, post.Deleted || post.Removed
+		current = models.Revision{Content: post.Content, Created: store.VersionTime(post.Created, post.Edited)}
+	} else if comment, err := e.store.GetComment(msg.TargetId); err == nil {
+		history, hidden = comment.History, comment.Deleted || comment.Removed
+		current = models.Revision{Content: comment.Content, Created: store.VersionTime(comment.Created, comment.Edited)}
+	} else {
+		hidden = true
+	}
+	if hidden {
+		e.fail(context, &store.NotFoundError{Kind: "post or comment", ID: msg.TargetId})
+		return
+	}
+
+	response := &pb.EditHistoryResponse{Revisions: make([]*pb.Revision, 0, len(history)+1)}
+	for _, revision := range history {
+		response.Revisions = append(response.Revisions, &pb.Revision{Content: revision.Content, CreatedAt: revision.Created})
+	}
+	response.Revisions = append(response.Revisions, &pb.Revision{Content: current.Content, CreatedAt: current.Created})
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
@@ -0,0 +1,69 @@
+package actor
+
+import (
+	"strings"
+	"testing"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/store/memory"
+)
+
+func TestEditAndDeleteContent(t *testing.T) {
+	engine := newTestEngine(t, memory.NewMemoryStore())
+
+	alice := engine.registerAndLogin("alice")
+	bob := engine.registerAndLogin("bob")
+	engine.createSubreddit(alice, "alice", "s1")
+	engine.succeed(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "bob", Token: bob})
+	engine.succeed(&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Title: "Hello", Content: "v1", Token: alice})
+	engine.succeed(&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Content: "first", Token: alice})
+	engine.succeed(&pb.CommentMessage{Id: "c2", PostId: "p1", ParentId: "c1", AuthorId: "bob", Content: "reply", Token: bob})
+
+	// Only the author edits or deletes.
+	engine.fail(&pb.EditPostMessage{PostId: "p1", AuthorId: "bob", Content: "hacked", Token: bob}, pb.ErrorCode_FORBIDDEN)
+	engine.fail(&pb.DeleteCommentMessage{CommentId: "c1", AuthorId: "bob", Token: bob}, pb.ErrorCode_FORBIDDEN)
+	engine.fail(&pb.EditCommentMessage{CommentId: "missing", AuthorId: "bob", Token: bob}, pb.ErrorCode_NOT_FOUND)
+
+	// An edit must leave some text.
+	engine.fail(&pb.EditPostMessage{PostId: "p1", AuthorId: "alice", Content: " \n\t", Token: alice}, pb.ErrorCode_INVALID)
+	engine.fail(&pb.EditCommentMessage{CommentId: "c1", AuthorId: "alice", Token: alice}, pb.ErrorCode_INVALID)
+
+	engine.succeed(&pb.EditPostMessage{PostId: "p1", AuthorId: "alice", Content: "v2", Token: alice})
+	feed := engine.request(&pb.GetFeedMessage{SubredditIds: []string{"s1"}}).(*pb.FeedResponse)
+	if len(feed.Posts) != 1 || feed.Posts[0].Content != "v2" || feed.Posts[0].EditedAt == 0 {
+		t.Errorf("Expected the edited post in the feed, got %v", feed.Posts)
+	}
+	history := engine.request(&pb.GetEditHistoryMessage{TargetId: "p1"}).(*pb.EditHistoryResponse)
+	if len(history.Revisions) != 2 || history.Revisions[0].Content != "v1" || history.Revisions[1].Content != "v2" {
+		t.Errorf("Expected revisions v1 and v2, got %v", history.Revisions)
+	}
+
+	// A deleted comment keeps its place, and its replies, in the tree.
+	engine.succeed(&pb.EditCommentMessage{CommentId: "c1", AuthorId: "alice", Content: "second", Token: alice})
+	engine.succeed(&pb.DeleteCommentMessage{CommentId: "c1", AuthorId: "alice", Token: alice})
+	tree := engine.request(&pb.GetCommentsMessage{PostId: "p1"}).(*pb.CommentsResponse)
+	if len(tree.Tree) != 1 || len(tree.Tree[0].Replies) != 1 {
+		t.Fatalf("Expected c1 with its reply, got %v", tree.Tree)
+	}
+	if c := tree.Tree[0].Comment; !c.Deleted || c.Content != "[deleted]" || c.AuthorId != "[deleted]" {
+		t.Errorf("Expected c1 to show as deleted, got %v", c)
+	}
+	engine.fail(&pb.EditCommentMessage{CommentId: "c1", AuthorId: "alice", Content: "third", Token: alice}, pb.ErrorCode_FORBIDDEN)
+	engine.fail(&pb.GetEditHistoryMessage{TargetId: "c1"}, pb.ErrorCode_NOT_FOUND)
+
+	// Deleting twice is the same as deleting once.
+	engine.succeed(&pb.DeletePostMessage{PostId: "p1", AuthorId: "alice", Token: alice})
+	engine.succeed(&pb.DeletePostMessage{PostId: "p1", AuthorId: "alice", Token: alice})
+	feed = engine.request(&pb.GetFeedMessage{SubredditIds: []string{"s1"}}).(*pb.FeedResponse)
+	if len(feed.Posts) != 0 {
+		t.Errorf("Expected no deleted post in the feed, got %v", feed.Posts)
+	}
+	engine.fail(&pb.CommentMessage{Id: "c3", PostId: "p1", AuthorId: "bob", Token: bob}, pb.ErrorCode_FORBIDDEN)
+
+	got := strings.Join(engine.events.Types(), ",")
+	for _, want := range []string{"post_edited", "comment_edited", "comment_deleted", "post_deleted"} {
+		if strings.Count(got, want) != 1 {
+			t.Errorf("Expected one %s event, got %s", want, got)
+		}
+	}
+}
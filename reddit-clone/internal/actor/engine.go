@@ -1,37 +1,156 @@
 package actor
 
 import (
+	"fmt"
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"log"
 	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/events"
 	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/ranking"
+	"reddit-clone/internal/search"
 	"reddit-clone/internal/store"
 	"reddit-clone/pkg/metrics"
 	"sort"
 	"time"
 )
 
-type EngineActor struct {
+// sessionTTL is how long a token issued by LoginMessage stays valid.
+const sessionTTL = 24 * time.Hour
+
+// userIdleTimeout is how long a UserActor without subscribers lives after
+// its last message.
+const userIdleTimeout = 5 * time.Minute
+
+// engineCore holds what every engine actor shares and implements the request
+// handlers. The router and its children each handle a subset of the messages.
+type engineCore struct {
 	store   store.Store
 	metrics *metrics.RedditMetrics
+	tokens  *auth.TokenManager
+	now     ranking.Clock
+	search  *search.Index
+	// userIdle is how long a UserActor without subscribers stays up.
+	userIdle time.Duration
+	// cluster is set on cluster members, whose users may be stored on
+	// another member, and members lists them.
+	cluster *cluster.Cluster
+	members *memberSet
+}
+
+// EngineActor is the entry point of the engine. It routes each request to the
+// actor that owns it: one SubredditActor per subreddit and one UserActor per
+// user, spawned as children on first use or, in cluster mode, activated as
+// grains on whichever member the cluster places them. Requests are
+// forwarded, so owners answer the original sender directly and a busy
+// subreddit only delays its own requests. A notifier child turns the events
+// published after writes into Notifications, which only reach users whose
+// UserActor is running and so may have subscribers; idle UserActors stop. The
+// search index, built from the store, follows the same events. In cluster
+// mode each member indexes the content it stores.
+type EngineActor struct {
+	*engineCore
+	children map[string]*actor.PID
+	notifier *actor.PID
+	indexing *eventstream.Subscription
+}
+
+// EngineOption customises an EngineActor built by NewEngineActor.
+type EngineOption func(*EngineActor)
+
+// WithTokenManager sets the session token issuer. Without it every engine
+// signs tokens with its own random key, so they do not survive a restart.
+func WithTokenManager(tokens *auth.TokenManager) EngineOption {
+	return func(e *EngineActor) {
+		e.tokens = tokens
+	}
+}
+
+// WithClock sets the time source used to rank feeds.
+func WithClock(now ranking.Clock) EngineOption {
+	return func(e *EngineActor) {
+		e.now = now
+	}
 }
 
-func NewEngineActor(store store.Store, metrics *metrics.RedditMetrics) *EngineActor {
-	return &EngineActor{
-		store:   store,
-		metrics: metrics,
+func NewEngineActor(store store.Store, metrics *metrics.RedditMetrics, opts ...EngineOption) *EngineActor {
+	e := &EngineActor{
+		engineCore: &engineCore{
+			store:    store,
+			metrics:  metrics,
+			now:      time.Now,
+			search:   search.NewIndex(),
+			userIdle: userIdleTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(e)
 	}
+	if e.tokens == nil {
+		e.tokens = auth.NewTokenManager(auth.GenerateSecret(), sessionTTL)
+	}
+	// Nothing writes to the store before the engine exists, so the index
+	// cannot miss an event.
+	if err := e.search.Rebuild(store); err != nil {
+		log.Printf("Engine: cannot rebuild the search index: %v", err)
+	}
+	return e
 }
 
 func (e *EngineActor) Receive(context actor.Context) {
+	// Routing reads the store, which may panic as well.
+	defer e.recoverRequest(context)
+
+	switch msg := context.Message().(type) {
+	case *actor.Started:
+		// Children do not survive a restart, so start with empty routes.
+		e.children = make(map[string]*actor.PID)
+		e.spawnNotifier(context)
+		// The index outlives restarts, so it subscribes only once.
+		if e.indexing == nil {
+			e.indexing = events.Attach(context.ActorSystem().EventStream, e.search)
+			e.recordMemberCounts()
+		}
+	case *actor.Stopping:
+		context.ActorSystem().EventStream.Unsubscribe(e.indexing)
+		e.indexing = nil
+	case *actor.Terminated:
+		if msg.Who.Equal(e.notifier) {
+			e.spawnNotifier(context)
+			return
+		}
+		e.forget(msg.Who)
+	case *pb.PingMessage:
+		context.Respond(&pb.PongMessage{})
+	default:
+		e.route(context, msg)
+	}
+}
+
+// handle answers a request with the matching handler. It reports whether the
+// message was a request the engine knows.
+func (e *engineCore) handle(context actor.Context) bool {
+	defer e.recoverRequest(context)
+
 	switch msg := context.Message().(type) {
 	case *pb.PingMessage:
 		context.Respond(&pb.PongMessage{})
 	case *pb.UserMessage:
 		e.handleUserMessage(context, msg)
+	case *pb.LoginMessage:
+		e.handleLoginMessage(context, msg)
+	case *pb.LogoutMessage:
+		e.handleLogoutMessage(context, msg)
 	case *pb.SubredditMessage:
 		e.handleSubredditMessage(context, msg)
 	case *pb.JoinSubredditMessage:
 		e.handleJoinSubredditMessage(context, msg)
+	case *pb.LeaveSubredditMessage:
+		e.handleLeaveSubredditMessage(context, msg)
 	case *pb.PostMessage:
 		e.handlePostMessage(context, msg)
 	case *pb.CommentMessage:
@@ -46,72 +165,496 @@ func (e *EngineActor) Receive(context actor.Context) {
 		e.handleGetComments(context, msg)
 	case *pb.GetDirectMessagesMessage:
 		e.handleGetDirectMessages(context, msg)
+	case *pb.GetUserProfileMessage:
+		e.handleGetUserProfile(context, msg)
+	case *pb.UpdateProfileMessage:
+		e.handleUpdateProfile(context, msg)
+	case *pb.ChangePasswordMessage:
+		e.handleChangePassword(context, msg)
+	case *pb.DeleteAccountMessage:
+		e.handleDeleteAccount(context, msg)
+	case *pb.ExportUserDataMessage:
+		e.handleExportUserData(context, msg)
+	case *pb.SearchMessage:
+		e.handleSearch(context, msg)
+	case *pb.AddModeratorMessage:
+		e.handleAddModerator(context, msg)
+	case *pb.BanUserMessage:
+		e.handleBanUser(context, msg)
+	case *pb.RemoveContentMessage:
+		e.handleRemoveContent(context, msg)
+	case *pb.LockPostMessage:
+		e.handleLockPost(context, msg)
+	case *pb.GetModLogMessage:
+		e.handleGetModLog(context, msg)
+	case *pb.GetSubredditMessage:
+		e.handleGetSubreddit(context, msg)
+	case *pb.ListSubredditsMessage:
+		e.handleListSubreddits(context, msg)
+	case *pb.GetTrendingSubredditsMessage:
+		e.handleGetTrendingSubreddits(context, msg)
+	case *pb.GetHomeFeedMessage:
+		e.handleGetHomeFeed(context, msg)
+	case *pb.GetSubscriptionsMessage:
+		e.handleGetSubscriptions(context, msg)
+	case *pb.GetSubredditMembersMessage:
+		e.handleGetSubredditMembers(context, msg)
+	case *pb.GetAllFeedMessage:
+		e.handleGetAllFeed(context, msg)
+	case *pb.GetConversationsMessage:
+		e.handleGetConversations(context, msg)
+	case *pb.MarkReadMessage:
+		e.handleMarkRead(context, msg)
+	case *pb.EditPostMessage:
+		e.handleEditPost(context, msg)
+	case *pb.EditCommentMessage:
+		e.handleEditComment(context, msg)
+	case *pb.DeletePostMessage:
+		e.handleDeletePost(context, msg)
+	case *pb.DeleteCommentMessage:
+		e.handleDeleteComment(context, msg)
+	case *pb.GetEditHistoryMessage:
+		e.handleGetEditHistory(context, msg)
+	default:
+		return false
+	}
+	return true
+}
+
+// route forwards a request to its owner. Comment and vote requests that do
+// not name their subreddit are resolved through the store, and logouts go to
+// the user the token was issued to.
+func (e *EngineActor) route(context actor.Context, msg interface{}) {
+	kind, identity, ok := GrainFor(msg)
+	if !ok {
+		switch msg := msg.(type) {
+		case *pb.LogoutMessage:
+			kind, identity = UserKind, e.tokenUser(msg.Token)
+		case *pb.CommentMessage:
+			kind, identity = SubredditKind, e.postSubreddit(msg.PostId)
+		case *pb.GetCommentsMessage:
+			kind, identity = SubredditKind, e.postSubreddit(msg.PostId)
+		case *pb.VoteMessage:
+			kind, identity = SubredditKind, e.targetSubreddit(msg.TargetId)
+		case *pb.RemoveContentMessage:
+			kind, identity = SubredditKind, e.targetSubreddit(msg.TargetId)
+		case *pb.LockPostMessage:
+			kind, identity = SubredditKind, e.postSubreddit(msg.PostId)
+		case *pb.EditPostMessage:
+			kind, identity = SubredditKind, e.postSubreddit(msg.PostId)
+		case *pb.DeletePostMessage:
+			kind, identity = SubredditKind, e.postSubreddit(msg.PostId)
+		case *pb.EditCommentMessage:
+			kind, identity = SubredditKind, e.targetSubreddit(msg.CommentId)
+		case *pb.DeleteCommentMessage:
+			kind, identity = SubredditKind, e.targetSubreddit(msg.CommentId)
+		case *pb.GetEditHistoryMessage:
+			kind, identity = SubredditKind, e.targetSubreddit(msg.TargetId)
+		case *pb.GetFeedMessage, *pb.GetAllFeedMessage,
+			*pb.SearchMessage, *pb.GetSubredditMessage, *pb.ListSubredditsMessage,
+			*pb.GetTrendingSubredditsMessage:
+			// Feeds over several subreddits, searches, listings and lookups
+			// by name are read by a worker, which in cluster mode gathers
+			// them from every member.
+		default:
+			// Not a request, e.g. a lifecycle message.
+			return
+		}
+	}
 
+	// Only a running UserActor can have subscribers, so a notification does
+	// not start one. Grains cannot be looked up without activating them, so
+	// in cluster mode they stop again when idle.
+	if _, ok := msg.(*pb.Notification); ok && e.cluster == nil {
+		if pid, running := e.children[childName(kind, identity)]; running {
+			context.Forward(pid)
+		}
+		return
+	}
+
+	// Requests that cannot be routed go to a worker, which answers with the
+	// store's error or reads across subreddits.
+	var pid *actor.PID
+	if identity != "" {
+		if e.cluster != nil {
+			pid = e.cluster.Get(identity, kind)
+		} else {
+			pid = e.child(context, kind, identity)
+		}
 	}
+	if pid == nil {
+		pid = context.SpawnPrefix(actor.PropsFromProducer(func() actor.Actor {
+			return &requestWorker{engineCore: e.engineCore}
+		}), workerPrefix)
+	}
+	context.Forward(pid)
 }
 
-func (e *EngineActor) handleJoinSubredditMessage(context actor.Context, msg *pb.JoinSubredditMessage) {
+// owner returns where an actor of the engine sends a request owned by another
+// grain: to the grain in cluster mode, or else to the engine, which routes it
+// to its child.
+func (e *engineCore) owner(context actor.Context, kind, identity string) *actor.PID {
+	if e.cluster != nil {
+		return e.cluster.Get(identity, kind)
+	}
+	return context.Parent()
+}
+
+// child returns the local owner of identity, spawning it on first use.
+func (e *EngineActor) child(context actor.Context, kind, identity string) *actor.PID {
+	name := childName(kind, identity)
+	if pid, exists := e.children[name]; exists {
+		return pid
+	}
+	pid, err := context.SpawnNamed(actor.PropsFromProducer(func() actor.Actor {
+		return e.newGrain(kind, identity)
+	}), name)
+	if err != nil {
+		return nil
+	}
+	e.children[name] = pid
+	return pid
+}
+
+func childName(kind, identity string) string {
+	return kind + "-" + identity
+}
+
+func (e *EngineActor) spawnNotifier(context actor.Context) {
+	e.notifier = context.SpawnPrefix(actor.PropsFromProducer(func() actor.Actor {
+		return &notifier{store: e.store, now: e.now}
+	}), notifierPrefix)
+}
+
+func (e *EngineActor) forget(pid *actor.PID) {
+	for name, child := range e.children {
+		if child.Equal(pid) {
+			delete(e.children, name)
+		}
+	}
+}
+
+// recordMemberCounts sets the member gauge of every stored subreddit, which
+// join and leave keep current from then on.
+func (e *EngineActor) recordMemberCounts() {
+	subreddits, err := e.store.ListSubreddits()
+	if err != nil {
+		log.Printf("Engine: cannot count subreddit members: %v", err)
+		return
+	}
+	for _, subreddit := range subreddits {
+		e.metrics.UpdateSubredditMembers(subreddit.ID, float64(len(subreddit.Members)))
+	}
+}
+
+// recordMembers sets the member gauge of a subreddit from its stored members,
+// so that it counts every change that reached the store.
+func (e *engineCore) recordMembers(subredditID string) {
+	subreddit, err := e.store.GetSubreddit(subredditID)
+	if err != nil {
+		log.Printf("Engine: cannot count the members of %s: %v", subredditID, err)
+		return
+	}
+	e.metrics.UpdateSubredditMembers(subredditID, float64(len(subreddit.Members)))
+}
+
+// postSubreddit returns the subreddit of a post, or "" if it does not exist.
+func (e *engineCore) postSubreddit(postID string) string {
+	post, err := e.store.GetPost(postID)
+	if err != nil {
+		return ""
+	}
+	return post.SubredditID
+}
+
+// targetSubreddit returns the subreddit of a vote or moderation target, which
+// is either a post or a comment.
+func (e *engineCore) targetSubreddit(targetID string) string {
+	if subredditID := e.postSubreddit(targetID); subredditID != "" {
+		return subredditID
+	}
+	comment, err := e.store.GetComment(targetID)
+	if err != nil {
+		return ""
+	}
+	return e.postSubreddit(comment.PostID)
+}
+
+// requestWorker answers a single request and stops.
+type requestWorker struct {
+	*engineCore
+}
+
+func (w *requestWorker) Receive(context actor.Context) {
+	if w.handle(context) {
+		context.Stop(context.Self())
+	}
+}
+
+func (e *engineCore) handleJoinSubredditMessage(context actor.Context, msg *pb.JoinSubredditMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
 
-	err := e.store.JoinSubreddit(msg.SubredditId, msg.UserId)
+	// Membership only changes in the subreddit's actor, so it cannot change
+	// between this read and the write.
+	subreddit, err := e.store.GetSubreddit(msg.SubredditId)
+	if err == nil && subreddit.Members[msg.UserId] {
+		e.metrics.RecordRequest(time.Since(start).Seconds())
+		context.Respond(&pb.SuccessResponse{Message: "Already a member of subreddit"})
+		return
+	}
+	if err == nil {
+		err = e.store.JoinSubreddit(msg.SubredditId, msg.UserId)
+	}
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
-	e.metrics.UpdateSubredditMembers(msg.SubredditId, 1)
+	e.recordSubscription(context, msg.UserId, msg.SubredditId, true)
+	e.publish(context, &events.SubredditJoined{SubredditID: msg.SubredditId, UserID: msg.UserId})
+	e.recordMembers(msg.SubredditId)
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Joined subreddit successfully"})
 }
 
-func (e *EngineActor) handleUserMessage(context actor.Context, msg *pb.UserMessage) {
+func (e *engineCore) handleLeaveSubredditMessage(context actor.Context, msg *pb.LeaveSubredditMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+
+	subreddit, err := e.store.GetSubreddit(msg.SubredditId)
+	if err == nil && !subreddit.Members[msg.UserId] {
+		e.metrics.RecordRequest(time.Since(start).Seconds())
+		context.Respond(&pb.SuccessResponse{Message: "Not a member of subreddit"})
+		return
+	}
+	if err == nil {
+		err = e.store.LeaveSubreddit(msg.SubredditId, msg.UserId)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.recordSubscription(context, msg.UserId, msg.SubredditId, false)
+	e.publish(context, &events.SubredditLeft{SubredditID: msg.SubredditId, UserID: msg.UserId})
+	e.recordMembers(msg.SubredditId)
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Left subreddit successfully"})
+}
+
+// recordSubscription tells the user's UserActor, which may live on another
+// member, that they joined or left a subreddit.
+func (e *engineCore) recordSubscription(context actor.Context, userID, subredditID string, joined bool) {
+	context.Send(e.owner(context, UserKind, userID), &pb.SubscriptionMessage{
+		UserId:      userID,
+		SubredditId: subredditID,
+		Joined:      joined,
+	})
+}
+
+// handleGetSubscriptions lists the subreddits a user has joined. It runs in
+// the user's actor and asks each subreddit's actor for its description.
+func (e *engineCore) handleGetSubscriptions(context actor.Context, msg *pb.GetSubscriptionsMessage) {
+	start := time.Now()
+
+	subredditIDs, err := e.store.GetUserSubreddits(msg.UserId)
+	var answers []interface{}
+	if err == nil {
+		answers, err = e.askEach(context, SubredditKind, subredditIDs, func(subredditID string) interface{} {
+			return &pb.GetSubredditMessage{Id: subredditID}
+		})
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	response := &pb.SubredditsResponse{Subreddits: make([]*pb.SubredditInfo, 0, len(subredditIDs))}
+	for _, answer := range answers {
+		info, ok := answer.(*pb.SubredditInfo)
+		if !ok {
+			e.fail(context, fmt.Errorf("unexpected answer %T", answer))
+			return
+		}
+		response.Subreddits = append(response.Subreddits, info)
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
+
+func memberKey(userID string) pagination.Key {
+	return pagination.Key{ID: userID}
+}
+
+func (e *engineCore) handleGetSubredditMembers(context actor.Context, msg *pb.GetSubredditMembersMessage) {
+	start := time.Now()
+
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	subreddit, err := e.store.GetSubreddit(msg.SubredditId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	members := make([]string, 0, len(subreddit.Members))
+	for userID := range subreddit.Members {
+		members = append(members, userID)
+	}
+	sort.Strings(members)
+	listed := pagination.Paginate(members, memberKey, pagination.Order{}, page, time.Time{})
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SubredditMembersResponse{
+		UserIds:    listed.Items,
+		Total:      int32(len(members)),
+		NextCursor: listed.Next,
+		PrevCursor: listed.Prev,
+	})
+}
+
+func (e *engineCore) handleUserMessage(context actor.Context, msg *pb.UserMessage) {
+	start := time.Now()
+	if msg.Password == "" {
+		e.fail(context, invalidf("password is required"))
+		return
+	}
+	// Deleted accounts' content is credited to this ID, so nobody may own it.
+	if msg.UserId == models.DeletedAuthor {
+		e.fail(context, invalidf("user ID %s is reserved", msg.UserId))
+		return
+	}
+
+	hash, err := auth.HashPassword(msg.Password)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
 	user := &models.User{
 		ID:       msg.UserId,
 		Username: msg.Username,
-		Password: msg.Password,
+		Password: hash,
 		Created:  time.Now().Unix(),
 	}
 
-	err := e.store.CreateUser(user)
+	err = e.store.CreateUser(user)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	e.publish(context, &events.UserRegistered{UserID: user.ID, Username: user.Username, Created: user.Created})
 	e.metrics.TotalUsers.Inc()
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "User registered successfully"})
 }
 
-func (e *EngineActor) handleSubredditMessage(context actor.Context, msg *pb.SubredditMessage) {
+func (e *engineCore) handleGetUserProfile(context actor.Context, msg *pb.GetUserProfileMessage) {
 	start := time.Now()
 
+	user, err := e.store.GetUser(msg.UserId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.UserProfileResponse{
+		UserId:       user.ID,
+		Username:     user.Username,
+		PostKarma:    user.PostKarma,
+		CommentKarma: user.CommentKarma,
+		TotalKarma:   user.Karma,
+		CreatedAt:    user.Created,
+		DisplayName:  user.DisplayName,
+		Bio:          user.Bio,
+		Deleted:      user.Deleted,
+	})
+}
+
+func (e *engineCore) handleLoginMessage(context actor.Context, msg *pb.LoginMessage) {
+	start := time.Now()
+
+	// Unknown users and wrong passwords get the same answer so that logins
+	// cannot be used to probe for user IDs.
+	user, err := e.store.GetUser(msg.UserId)
+	if err == nil {
+		err = auth.CheckPassword(user.Password, msg.Password)
+	}
+	if err != nil {
+		e.fail(context, auth.ErrInvalidCredentials)
+		return
+	}
+
+	token, claims, err := e.tokens.Issue(user.ID)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.LoginResponse{
+		UserId:    user.ID,
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt,
+	})
+}
+
+// publish announces a write the store accepted on the actor system's
+// EventStream. See package events.
+func (e *engineCore) publish(context actor.Context, event events.Event) {
+	context.ActorSystem().EventStream.Publish(event)
+}
+
+func (e *engineCore) handleSubredditMessage(context actor.Context, msg *pb.SubredditMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.CreatorId) {
+		return
+	}
+	if msg.Name == "" {
+		e.fail(context, invalidf("subreddit name is required"))
+		return
+	}
+
 	subreddit := &models.Subreddit{
 		ID:          msg.Id,
 		Name:        msg.Name,
 		Description: msg.Description,
 		CreatorID:   msg.CreatorId,
 		Members:     make(map[string]bool),
+		Moderators:  map[string]bool{msg.CreatorId: true},
 		Created:     time.Now().Unix(),
 	}
 
 	err := e.store.CreateSubreddit(subreddit)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
-	e.metrics.UpdateSubredditMembers(subreddit.Name, 1)
+	e.publish(context, &events.SubredditCreated{Subreddit: subreddit})
+	e.metrics.UpdateSubredditMembers(subreddit.ID, float64(len(subreddit.Members)))
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Subreddit created successfully"})
 }
 
-func (e *EngineActor) handlePostMessage(context actor.Context, msg *pb.PostMessage) {
+func (e *engineCore) handlePostMessage(context actor.Context, msg *pb.PostMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
+	if !e.checkNotBanned(context, msg.SubredditId, msg.AuthorId) {
+		return
+	}
 
 	post := &models.Post{
 		ID:          msg.Id,
@@ -123,20 +666,26 @@ func (e *EngineActor) handlePostMessage(context actor.Context, msg *pb.PostMessa
 		Votes:       make(map[string]bool),
 	}
 
-	err := e.store.CreatePost(post)
+	err := e.checkPost(post)
+	if err == nil {
+		err = e.store.CreatePost(post)
+	}
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	e.publish(context, &events.PostCreated{Post: post})
 	e.metrics.PostsCreated.Inc()
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Post created successfully"})
 }
 
-func (e *EngineActor) handleCommentMessage(context actor.Context, msg *pb.CommentMessage) {
+func (e *engineCore) handleCommentMessage(context actor.Context, msg *pb.CommentMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.AuthorId) {
+		return
+	}
 
 	comment := &models.Comment{
 		ID:       msg.Id,
@@ -147,35 +696,123 @@ func (e *EngineActor) handleCommentMessage(context actor.Context, msg *pb.Commen
 		Created:  time.Now().Unix(),
 	}
 
-	err := e.store.AddComment(comment)
+	post, err := e.checkComment(comment)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	if post.Deleted {
+		e.fail(context, forbiddenf("post %s is deleted", post.ID))
+		return
+	}
+	if post.Locked || post.Removed {
+		e.fail(context, forbiddenf("post %s is locked", post.ID))
+		return
+	}
+	if !e.checkNotBanned(context, post.SubredditID, msg.AuthorId) {
+		return
+	}
+
+	err = e.store.AddComment(comment)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	e.publish(context, &events.CommentAdded{Comment: comment, SubredditID: post.SubredditID})
 	e.metrics.CommentsCreated.Inc()
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Comment created successfully"})
 }
 
-func (e *EngineActor) handleVoteMessage(context actor.Context, msg *pb.VoteMessage) {
+func (e *engineCore) handleVoteMessage(context actor.Context, msg *pb.VoteMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+	if err := e.checkVoteTarget(msg.TargetId); err != nil {
+		e.fail(context, err)
+		return
+	}
+	if !e.checkNotBanned(context, e.targetSubreddit(msg.TargetId), msg.UserId) {
+		return
+	}
+
+	karma, before := e.targetKarma(msg.TargetId)
+	if msg.Clear {
+		if err := e.store.ClearVote(msg.TargetId, msg.UserId); err != nil {
+			e.fail(context, err)
+			return
+		}
+
+		e.creditKarma(context, msg.UserId, karma, before)
+		e.publish(context, &events.VoteCast{TargetID: msg.TargetId, UserID: msg.UserId, Cleared: true})
+		e.metrics.RecordRequest(time.Since(start).Seconds())
+		context.Respond(&pb.SuccessResponse{Message: "Vote cleared successfully"})
+		return
+	}
 
 	err := e.store.Vote(msg.TargetId, msg.UserId, msg.IsUpvote)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	e.creditKarma(context, msg.UserId, karma, before)
+	e.publish(context, &events.VoteCast{TargetID: msg.TargetId, UserID: msg.UserId, IsUpvote: msg.IsUpvote})
 	e.metrics.VotesRecorded.Inc()
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Vote recorded successfully"})
 }
 
-func (e *EngineActor) handleDirectMessage(context actor.Context, msg *pb.DirectMessageMessage) {
+// targetKarma returns the KarmaMessage that credits a vote on the post or
+// comment targetID, without its deltas, and the target's current karma.
+func (e *engineCore) targetKarma(targetID string) (*pb.KarmaMessage, int32) {
+	if post, err := e.store.GetPost(targetID); err == nil {
+		return &pb.KarmaMessage{
+			UserId:      post.AuthorID,
+			TargetId:    post.ID,
+			PostId:      post.ID,
+			SubredditId: post.SubredditID,
+		}, post.Karma
+	}
+	if comment, err := e.store.GetComment(targetID); err == nil {
+		return &pb.KarmaMessage{
+			UserId:      comment.AuthorID,
+			TargetId:    comment.ID,
+			PostId:      comment.PostID,
+			SubredditId: e.postSubreddit(comment.PostID),
+		}, comment.Karma
+	}
+	return nil, 0
+}
+
+// creditKarma sends the change a vote made to the target's karma to its
+// author's UserActor, which may live on another member. Content of deleted
+// accounts earns no karma.
+func (e *engineCore) creditKarma(context actor.Context, voterID string, karma *pb.KarmaMessage, before int32) {
+	if karma == nil || karma.UserId == models.DeletedAuthor {
+		return
+	}
+	_, after := e.targetKarma(karma.TargetId)
+	delta := after - before
+	if delta == 0 {
+		return
+	}
+	if karma.TargetId == karma.PostId {
+		karma.PostKarma = delta
+	} else {
+		karma.CommentKarma = delta
+	}
+	karma.VoterId = voterID
+	context.Send(e.owner(context, UserKind, karma.UserId), karma)
+}
+
+func (e *engineCore) handleDirectMessage(context actor.Context, msg *pb.DirectMessageMessage) {
 	start := time.Now()
+	if !e.authorize(context, msg.GetToken(), msg.GetFromId()) {
+		return
+	}
 
 	message := &models.DirectMessage{
 		ID:        msg.GetId(),      // Use GetId() method
@@ -186,193 +823,415 @@ func (e *EngineActor) handleDirectMessage(context actor.Context, msg *pb.DirectM
 		ReplyToID: msg.GetReplyToId(),
 	}
 
-	err := e.store.SendMessage(message)
+	err := e.checkRecipient(message.ToID)
+	if err == nil && message.ReplyToID != "" {
+		err = e.checkReply(message.FromID, message.ToID, message.ReplyToID)
+	}
+	if err == nil {
+		err = e.store.SendMessage(message)
+	}
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	e.copyMessage(context, message)
+	e.publish(context, &events.MessageSent{Message: message})
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(&pb.SuccessResponse{Message: "Message sent successfully"})
 }
 
-func (e *EngineActor) handleGetFeed(context actor.Context, msg *pb.GetFeedMessage) {
-	start := time.Now()
+// feedOrder matches the order ranking.Rank returns posts in.
+var feedOrder = pagination.Order{ScoreDescending: true, TimeDescending: true}
 
-	// Get posts from subscribed subreddits
-	var feed []*models.Post
-	for _, subredditID := range msg.SubredditIds {
-		posts, err := e.store.GetSubredditPosts(subredditID)
-		if err != nil {
-			e.metrics.RecordError()
-			context.Respond(&pb.ErrorResponse{Error: err.Error()})
-			return
-		}
-		feed = append(feed, posts...)
-	}
+func feedKey(entry ranking.Scored) pagination.Key {
+	return pagination.Key{Score: entry.Score, Time: entry.Post.Created, ID: entry.Post.ID}
+}
 
-	// Sort by creation time and karma
-	sort.Slice(feed, func(i, j int) bool {
-		scoreI := float64(feed[i].Karma) / time.Since(time.Unix(feed[i].Created, 0)).Hours()
-		scoreJ := float64(feed[j].Karma) / time.Since(time.Unix(feed[j].Created, 0)).Hours()
-		return scoreI > scoreJ
-	})
+func (e *engineCore) handleGetFeed(context actor.Context, msg *pb.GetFeedMessage) {
+	// A feed of one subreddit is sent to that subreddit's actor (see
+	// GrainFor), which stores its posts.
+	e.answerFeed(context, msg, func() ([]*models.Post, error) {
+		if len(msg.SubredditIds) == 1 {
+			return e.storedPosts(msg.SubredditIds)
+		}
+		return e.gatherPosts(context, msg.SubredditIds)
+	}, nil)
+}
 
-	// Convert to proto message
-	response := &pb.FeedResponse{
-		Posts: make([]*pb.PostMessage, 0, len(feed)),
-	}
-	for _, post := range feed {
-		response.Posts = append(response.Posts, &pb.PostMessage{
-			Id:          post.ID,
-			SubredditId: post.SubredditID,
-			AuthorId:    post.AuthorID,
-			Title:       post.Title,
-			Content:     post.Content,
-			CreatedAt:   post.Created,
-			IsRepost:    false,
-		})
+// handleGetHomeFeed answers with the feed of the subreddits the user has
+// joined, so clients need not track their memberships. It runs in the user's
+// actor, which gathers the posts from each subreddit's actor.
+func (e *engineCore) handleGetHomeFeed(context actor.Context, msg *pb.GetHomeFeedMessage) {
+	subredditIDs, err := e.store.GetUserSubreddits(msg.UserId)
+	if err != nil {
+		e.fail(context, err)
+		return
 	}
+	e.answerFeed(context, &pb.GetFeedMessage{
+		Limit:  msg.Limit,
+		Sort:   msg.Sort,
+		Window: msg.Window,
+		After:  msg.After,
+		Before: msg.Before,
+	}, func() ([]*models.Post, error) {
+		return e.gatherPosts(context, subredditIDs)
+	}, nil)
+}
 
-	e.metrics.RecordRequest(time.Since(start).Seconds())
-	context.Respond(response)
+func (e *engineCore) handleGetAllFeed(context actor.Context, msg *pb.GetAllFeedMessage) {
+	var keep func(*models.Post) bool
+	if msg.Popular {
+		keep = func(post *models.Post) bool { return post.Karma > 0 }
+	}
+	e.answerFeed(context, &pb.GetFeedMessage{
+		Limit:  msg.Limit,
+		Sort:   msg.Sort,
+		Window: msg.Window,
+		After:  msg.After,
+		Before: msg.Before,
+	}, func() ([]*models.Post, error) {
+		return e.allPosts(context)
+	}, keep)
 }
 
-func (e *EngineActor) handleGetComments(context actor.Context, msg *pb.GetCommentsMessage) {
+// answerFeed ranks the posts that load returns and answers with the page msg
+// asks for. keep, if set, selects the posts that are ranked.
+func (e *engineCore) answerFeed(context actor.Context, msg *pb.GetFeedMessage, load func() ([]*models.Post, error), keep func(*models.Post) bool) {
 	start := time.Now()
 
-	comments, err := e.store.GetComments(msg.PostId)
+	window, err := ranking.ParseWindow(msg.Window)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
+		return
+	}
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
 		return
 	}
 
-	// Build comment tree
-	commentMap := make(map[string]*models.Comment)
-	rootComments := make([]*models.Comment, 0)
-
-	for _, comment := range comments {
-		commentMap[comment.ID] = comment
-		if comment.ParentID == "" {
-			rootComments = append(rootComments, comment)
-		} else {
-			if parent, exists := commentMap[comment.ParentID]; exists {
-				parent.Children = append(parent.Children, comment.ID)
+	posts, err := load()
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	if keep != nil {
+		kept := posts[:0]
+		for _, post := range posts {
+			if keep(post) {
+				kept = append(kept, post)
 			}
 		}
+		posts = kept
 	}
 
-	// Convert to proto message
-	response := &pb.CommentsResponse{
-		Comments: make([]*pb.CommentMessage, 0, len(comments)),
-	}
-
-	// Helper function to convert comment tree to proto
-	var convertComment func(*models.Comment) *pb.CommentMessage
-	convertComment = func(comment *models.Comment) *pb.CommentMessage {
-		protoComment := &pb.CommentMessage{
-			Id:        comment.ID,
-			PostId:    comment.PostID,
-			ParentId:  comment.ParentID,
-			AuthorId:  comment.AuthorID,
-			Content:   comment.Content,
-			CreatedAt: comment.Created,
-		}
-		return protoComment
+	// Later pages are ranked as of the first one so that posts do not move
+	// between pages as time passes.
+	now := page.Now(e.now())
+	ranked, err := ranking.Rank(posts, msg.Sort, ranking.Options{Now: now, Window: window})
+	if err != nil {
+		e.fail(context, err)
+		return
 	}
+	feed := pagination.Paginate(ranked, feedKey, feedOrder, page, now)
 
-	// Convert all comments starting from root
-	for _, rootComment := range rootComments {
-		response.Comments = append(response.Comments, convertComment(rootComment))
+	// Convert to proto message
+	response := &pb.FeedResponse{
+		Posts:      make([]*pb.PostMessage, 0, len(feed.Items)),
+		NextCursor: feed.Next,
+		PrevCursor: feed.Prev,
+	}
+	for _, entry := range feed.Items {
+		response.Posts = append(response.Posts, postMessage(entry.Post))
 	}
 
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(response)
 }
 
-func (e *EngineActor) GetFeed(context actor.Context, msg *pb.GetFeedMessage) {
-	start := time.Now()
-	subredditIds := msg.GetSubredditIds()
-	limit := msg.GetLimit()
-
-	var feed []*models.Post
-	for _, subredditId := range subredditIds {
-		posts, err := e.store.GetSubredditPosts(subredditId)
+// storedPosts returns the posts of subredditIDs that feeds show.
+func (e *engineCore) storedPosts(subredditIDs []string) ([]*models.Post, error) {
+	var posts []*models.Post
+	for _, subredditID := range subredditIDs {
+		subredditPosts, err := e.store.GetSubredditPosts(subredditID)
 		if err != nil {
-			e.metrics.RecordError()
-			continue
+			return nil, err
+		}
+		// Removed and deleted posts stay in the store for the moderation
+		// log and edit history.
+		for _, post := range subredditPosts {
+			if !post.Removed && !post.Deleted {
+				posts = append(posts, post)
+			}
 		}
-		feed = append(feed, posts...)
 	}
+	return posts, nil
+}
 
-	// Sort posts by relevance (using a simple time-based algorithm)
-	sort.Slice(feed, func(i, j int) bool {
-		scoreI := calculateRelevanceScore(feed[i])
-		scoreJ := calculateRelevanceScore(feed[j])
-		return scoreI > scoreJ
+// gatherPosts is storedPosts for actors that may not share the store of the
+// subreddits: in cluster mode it asks each subreddit's actor for its feed.
+func (e *engineCore) gatherPosts(context actor.Context, subredditIDs []string) ([]*models.Post, error) {
+	if e.cluster == nil {
+		return e.storedPosts(subredditIDs)
+	}
+	answers, err := e.askEach(context, SubredditKind, subredditIDs, func(subredditID string) interface{} {
+		return &pb.GetFeedMessage{SubredditIds: []string{subredditID}, Sort: ranking.New}
 	})
+	if err != nil {
+		return nil, err
+	}
+	var posts []*models.Post
+	for _, answer := range answers {
+		feed, ok := answer.(*pb.FeedResponse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected answer %T", answer)
+		}
+		for _, post := range feed.Posts {
+			posts = append(posts, postFromMessage(post))
+		}
+	}
+	return posts, nil
+}
 
-	// Limit the number of posts
-	if len(feed) > int(limit) {
-		feed = feed[:limit]
+// allPosts returns the posts of every subreddit that feeds show, asking the
+// other cluster members for those they store.
+func (e *engineCore) allPosts(context actor.Context) ([]*models.Post, error) {
+	subreddits, err := e.store.ListSubreddits()
+	if err != nil {
+		return nil, err
+	}
+	subredditIDs := make([]string, 0, len(subreddits))
+	for _, subreddit := range subreddits {
+		subredditIDs = append(subredditIDs, subreddit.ID)
+	}
+	posts, err := e.storedPosts(subredditIDs)
+	var answers []interface{}
+	if err == nil {
+		answers, err = e.askOtherMembers(context, &pb.GetAllFeedMessage{Sort: ranking.New})
 	}
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		feed, ok := answer.(*pb.FeedResponse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected answer %T", answer)
+		}
+		for _, post := range feed.Posts {
+			posts = append(posts, postFromMessage(post))
+		}
+	}
+	return posts, nil
+}
 
-	// Convert to proto message response
-	response := &pb.FeedResponse{
-		Posts: make([]*pb.PostMessage, 0, len(feed)),
+func postMessage(post *models.Post) *pb.PostMessage {
+	return &pb.PostMessage{
+		Id:          post.ID,
+		SubredditId: post.SubredditID,
+		AuthorId:    post.AuthorID,
+		Title:       post.Title,
+		Content:     post.Content,
+		CreatedAt:   post.Created,
+		IsRepost:    false,
+		Score:       post.Karma,
+		Locked:      post.Locked,
+		EditedAt:    post.Edited,
+		Ups:         post.Ups,
+		Downs:       post.Downs,
 	}
-	for _, post := range feed {
-		response.Posts = append(response.Posts, &pb.PostMessage{
-			Id:          post.ID,
-			SubredditId: post.SubredditID,
-			AuthorId:    post.AuthorID,
-			Title:       post.Title,
-			Content:     post.Content,
-			CreatedAt:   post.Created,
-			IsRepost:    false, // Assuming we don't track reposts for now
-		})
+}
+
+// postFromMessage is the inverse of postMessage, for posts in the feed of
+// another member's subreddit.
+func postFromMessage(msg *pb.PostMessage) *models.Post {
+	return &models.Post{
+		ID:          msg.Id,
+		SubredditID: msg.SubredditId,
+		AuthorID:    msg.AuthorId,
+		Title:       msg.Title,
+		Content:     msg.Content,
+		Created:     msg.CreatedAt,
+		Karma:       msg.Score,
+		Ups:         msg.Ups,
+		Downs:       msg.Downs,
+		Locked:      msg.Locked,
+		Edited:      msg.EditedAt,
+	}
+}
+
+func (e *engineCore) handleGetComments(context actor.Context, msg *pb.GetCommentsMessage) {
+	start := time.Now()
+
+	opts, err := newCommentTreeOptions(msg)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	comments, err := e.store.GetComments(msg.PostId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	tree, err := buildCommentTree(comments, opts)
+	if err != nil {
+		e.fail(context, err)
+		return
 	}
 
 	e.metrics.RecordRequest(time.Since(start).Seconds())
-	context.Respond(response)
+	context.Respond(&pb.CommentsResponse{
+		Comments:     flattenCommentTree(tree.Items),
+		Tree:         tree.Items,
+		MoreComments: int32(tree.Remaining),
+		NextCursor:   tree.Next,
+		PrevCursor:   tree.Prev,
+	})
 }
 
-func calculateRelevanceScore(post *models.Post) float64 {
-	// Simple relevance score based on time and karma
-	// You can make this more sophisticated by considering more factors
-	timeFactor := 1.0 / float64(time.Since(time.Unix(post.Created, 0)).Hours()+2)
-	karmaFactor := float64(post.Karma)
-	return timeFactor * karmaFactor
+// messageKey orders an inbox or conversation oldest first, as store.Store
+// returns them.
+func messageKey(message *models.DirectMessage) pagination.Key {
+	return pagination.Key{Time: message.Timestamp, ID: message.ID}
 }
 
-func (e *EngineActor) handleGetDirectMessages(context actor.Context, msg *pb.GetDirectMessagesMessage) {
+func (e *engineCore) handleGetDirectMessages(context actor.Context, msg *pb.GetDirectMessagesMessage) {
 	start := time.Now()
 	userID := msg.GetUserId()
+	if !e.authorize(context, msg.Token, userID) {
+		return
+	}
 
-	messages, err := e.store.GetMessages(userID)
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
 	if err != nil {
-		e.metrics.RecordError()
-		context.Respond(&pb.ErrorResponse{Error: err.Error()})
+		e.fail(context, err)
 		return
 	}
 
+	var messages []*models.DirectMessage
+	if msg.WithId != "" {
+		messages, err = e.store.GetConversation(userID, msg.WithId)
+	} else {
+		messages, err = e.store.GetMessages(userID)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	inbox := pagination.Paginate(messages, messageKey, pagination.Order{}, page, time.Time{})
+
 	response := &pb.DirectMessagesResponse{
-		Messages: make([]*pb.DirectMessageMessage, 0, len(messages)),
+		Messages:   make([]*pb.DirectMessageMessage, 0, len(inbox.Items)),
+		NextCursor: inbox.Next,
+		PrevCursor: inbox.Prev,
 	}
 
-	for _, message := range messages {
-		response.Messages = append(response.Messages, &pb.DirectMessageMessage{
-			Id:        message.ID,
-			FromId:    message.FromID,
-			ToId:      message.ToID,
-			Content:   message.Content,
-			Timestamp: message.Timestamp,
-			ReplyToId: message.ReplyToID,
-		})
+	for _, message := range inbox.Items {
+		response.Messages = append(response.Messages, directMessage(message))
 	}
 
 	e.metrics.RecordRequest(time.Since(start).Seconds())
 	context.Respond(response)
 }
+
+func (e *engineCore) handleSearch(context actor.Context, msg *pb.SearchMessage) {
+	start := time.Now()
+
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	results, err := e.search.Search(search.Query{
+		Text:          msg.Query,
+		Kinds:         msg.Kinds,
+		SubredditID:   msg.SubredditId,
+		AuthorID:      msg.AuthorId,
+		CreatedAfter:  msg.CreatedAfter,
+		CreatedBefore: msg.CreatedBefore,
+	})
+	if err == nil {
+		results, err = e.gatherResults(context, msg, results)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	found := pagination.Paginate(results, search.Result.Key, search.Order, page, time.Time{})
+
+	response := &pb.SearchResponse{
+		Results:    make([]*pb.SearchResult, 0, len(found.Items)),
+		NextCursor: found.Next,
+		PrevCursor: found.Prev,
+	}
+	for _, result := range found.Items {
+		response.Results = append(response.Results, searchResult(result))
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
+
+// gatherResults adds the results of the other cluster members' search
+// indexes, which cover the content they store, to results, keeping them in
+// search.Order.
+func (e *engineCore) gatherResults(context actor.Context, msg *pb.SearchMessage, results []search.Result) ([]search.Result, error) {
+	answers, err := e.askOtherMembers(context, &pb.SearchMessage{
+		Query:         msg.Query,
+		Kinds:         msg.Kinds,
+		SubredditId:   msg.SubredditId,
+		AuthorId:      msg.AuthorId,
+		CreatedAfter:  msg.CreatedAfter,
+		CreatedBefore: msg.CreatedBefore,
+	})
+	if err != nil || len(answers) == 0 {
+		return results, err
+	}
+	for _, answer := range answers {
+		found, ok := answer.(*pb.SearchResponse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected answer %T", answer)
+		}
+		for _, result := range found.Results {
+			results = append(results, fromSearchResult(result))
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return search.Order.Compare(results[i].Key(), results[j].Key()) < 0
+	})
+	return results, nil
+}
+
+func searchResult(result search.Result) *pb.SearchResult {
+	return &pb.SearchResult{
+		Kind:        result.Kind,
+		Id:          result.ID,
+		SubredditId: result.SubredditID,
+		PostId:      result.PostID,
+		AuthorId:    result.AuthorID,
+		Title:       result.Title,
+		Content:     result.Content,
+		Score:       result.Score,
+		CreatedAt:   result.Created,
+	}
+}
+
+// fromSearchResult is the inverse of searchResult.
+func fromSearchResult(result *pb.SearchResult) search.Result {
+	return search.Result{
+		Document: search.Document{
+			Kind:        result.Kind,
+			ID:          result.Id,
+			SubredditID: result.SubredditId,
+			PostID:      result.PostId,
+			AuthorID:    result.AuthorId,
+			Title:       result.Title,
+			Content:     result.Content,
+			Created:     result.CreatedAt,
+		},
+		Score: result.Score,
+	}
+}
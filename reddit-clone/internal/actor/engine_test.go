@@ -1,13 +1,19 @@
 package actor
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
 	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
 	"reddit-clone/internal/store/memory"
 	"reddit-clone/pkg/metrics"
 )
@@ -37,9 +43,21 @@ func TestHandleGetDirectMessages(t *testing.T) {
 		t.Fatalf("Failed to spawn engine actor: %v", err)
 	}
 
+	// Only user2 may read their inbox.
+	token := registerAndLogin(t, rootContext, enginePID, "user2")
+	other := registerAndLogin(t, rootContext, enginePID, "user3")
+	result, _ := rootContext.RequestFuture(enginePID, &pb.GetDirectMessagesMessage{UserId: "user2", Token: other}, 5*time.Second).Result()
+	if resp, ok := result.(*pb.ErrorResponse); !ok || resp.Code != pb.ErrorCode_FORBIDDEN {
+		t.Errorf("Expected FORBIDDEN reading another user's inbox, got %v", result)
+	}
+	result, _ = rootContext.RequestFuture(enginePID, &pb.GetDirectMessagesMessage{UserId: "user2"}, 5*time.Second).Result()
+	if resp, ok := result.(*pb.ErrorResponse); !ok || resp.Code != pb.ErrorCode_UNAUTHENTICATED {
+		t.Errorf("Expected UNAUTHENTICATED reading an inbox without a session, got %v", result)
+	}
+
 	// Send GetDirectMessagesMessage to the engine actor
-	future := rootContext.RequestFuture(enginePID, &pb.GetDirectMessagesMessage{UserId: "user2"}, 5*time.Second)
-	result, err := future.Result()
+	future := rootContext.RequestFuture(enginePID, &pb.GetDirectMessagesMessage{UserId: "user2", Token: token}, 5*time.Second)
+	result, err = future.Result()
 	if err != nil {
 		t.Fatalf("Failed to get response from engine actor: %v", err)
 	}
@@ -112,12 +130,16 @@ func TestHandlePostMessage(t *testing.T) {
 		t.Fatalf("Failed to spawn engine actor: %v", err)
 	}
 
+	token := registerAndLogin(t, system.Root, enginePID, "user1")
+	createSubreddit(t, system.Root, enginePID, token, "user1", "subreddit1")
+
 	postMsg := &pb.PostMessage{
 		Id:          "post1",
 		SubredditId: "subreddit1",
 		AuthorId:    "user1",
 		Title:       "Test Post",
 		Content:     "This is a test post",
+		Token:       token,
 	}
 
 	future := system.Root.RequestFuture(enginePID, postMsg, 5*time.Second)
@@ -142,3 +164,701 @@ func TestHandlePostMessage(t *testing.T) {
 		t.Errorf("Expected post title 'Test Post', got '%s'", post.Title)
 	}
 }
+
+// registerAndLogin creates userID with a fixed password and returns a
+// session token for it.
+func registerAndLogin(t *testing.T, root *actor.RootContext, enginePID *actor.PID, userID string) string {
+	t.Helper()
+
+	result, err := root.RequestFuture(enginePID, &pb.UserMessage{
+		UserId:   userID,
+		Username: userID,
+		Password: "password123",
+	}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to register %s: %v", userID, err)
+	}
+	if _, ok := result.(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected SuccessResponse registering %s, got %v", userID, result)
+	}
+
+	result, err = root.RequestFuture(enginePID, &pb.LoginMessage{
+		UserId:   userID,
+		Password: "password123",
+	}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to log in %s: %v", userID, err)
+	}
+	login, ok := result.(*pb.LoginResponse)
+	if !ok {
+		t.Fatalf("Expected LoginResponse for %s, got %v", userID, result)
+	}
+	return login.Token
+}
+
+// createSubreddit creates subredditID with creatorID as its moderator, so the
+// creator may post in it without joining.
+func createSubreddit(t *testing.T, root *actor.RootContext, enginePID *actor.PID, token, creatorID, subredditID string) {
+	t.Helper()
+
+	result, err := root.RequestFuture(enginePID, &pb.SubredditMessage{
+		Id:        subredditID,
+		Name:      subredditID,
+		CreatorId: creatorID,
+		Token:     token,
+	}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to create subreddit %s: %v", subredditID, err)
+	}
+	if _, ok := result.(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected SuccessResponse creating %s, got %v", subredditID, result)
+	}
+}
+
+// testEngine is an engine on its own actor system, with helpers that send it
+// requests and fail the test on unexpected answers. It records the events the
+// engine publishes.
+type testEngine struct {
+	t       *testing.T
+	system  *actor.ActorSystem
+	pid     *actor.PID
+	metrics *metrics.RedditMetrics
+	events  *events.Recorder
+}
+
+func newTestEngine(t *testing.T, store store.Store, opts ...EngineOption) *testEngine {
+	system := actor.NewActorSystem()
+	e := &testEngine{t: t, system: system, metrics: metrics.NewRedditMetrics(), events: events.NewRecorder()}
+	events.Attach(system.EventStream, e.events)
+	engine := NewEngineActor(store, e.metrics, opts...)
+	e.pid = system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+	return e
+}
+
+func (e *testEngine) request(msg interface{}) interface{} {
+	e.t.Helper()
+	result, err := e.system.Root.RequestFuture(e.pid, msg, 5*time.Second).Result()
+	if err != nil {
+		e.t.Fatalf("Failed to get response for %T: %v", msg, err)
+	}
+	return result
+}
+
+func (e *testEngine) succeed(msg interface{}) {
+	e.t.Helper()
+	if result, ok := e.request(msg).(*pb.SuccessResponse); !ok {
+		e.t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+	}
+}
+
+func (e *testEngine) fail(msg interface{}, code pb.ErrorCode) {
+	e.t.Helper()
+	resp, ok := e.request(msg).(*pb.ErrorResponse)
+	if !ok || resp.Code != code {
+		e.t.Errorf("Expected %s for %T, got %v", code, msg, resp)
+	}
+}
+
+// failContaining expects an error whose message contains want.
+func (e *testEngine) failContaining(msg interface{}, want string) {
+	e.t.Helper()
+	resp, ok := e.request(msg).(*pb.ErrorResponse)
+	if !ok || !strings.Contains(resp.Error, want) {
+		e.t.Errorf("Expected an error containing %q for %T, got %v", want, msg, resp)
+	}
+}
+
+func (e *testEngine) registerAndLogin(userID string) string {
+	e.t.Helper()
+	return registerAndLogin(e.t, e.system.Root, e.pid, userID)
+}
+
+func (e *testEngine) createSubreddit(token, creatorID, subredditID string) {
+	e.t.Helper()
+	createSubreddit(e.t, e.system.Root, e.pid, token, creatorID, subredditID)
+}
+
+func TestPasswordIsHashedAndLoginChecksIt(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	registerAndLogin(t, system.Root, enginePID, "user1")
+
+	user, err := store.GetUser("user1")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if user.Password == "password123" {
+		t.Error("Expected the stored password to be hashed")
+	}
+
+	for _, login := range []*pb.LoginMessage{
+		{UserId: "user1", Password: "wrong"},
+		{UserId: "nobody", Password: "password123"},
+	} {
+		result, err := system.Root.RequestFuture(enginePID, login, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.ErrorResponse); !ok {
+			t.Errorf("Expected ErrorResponse for login %v, got %T", login, result)
+		}
+	}
+}
+
+func TestWritesRequireMatchingSessionToken(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	aliceToken := registerAndLogin(t, system.Root, enginePID, "alice")
+	registerAndLogin(t, system.Root, enginePID, "bob")
+
+	rejected := []interface{}{
+		&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice"},
+		&pb.PostMessage{Id: "p2", SubredditId: "s1", AuthorId: "bob", Token: aliceToken},
+		&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "bob", Token: aliceToken},
+		&pb.VoteMessage{TargetId: "p1", UserId: "bob", IsUpvote: true, Token: aliceToken},
+		&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "bob", Token: "garbage"},
+		&pb.DirectMessageMessage{Id: "m1", FromId: "bob", ToId: "alice", Token: aliceToken},
+	}
+	for _, msg := range rejected {
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.ErrorResponse); !ok {
+			t.Errorf("Expected %T with a foreign or missing token to be rejected, got %T", msg, result)
+		}
+	}
+	if _, err := store.GetPost("p2"); err == nil {
+		t.Error("Expected the impersonated post not to be stored")
+	}
+
+	result, _ := system.Root.RequestFuture(enginePID, &pb.LogoutMessage{Token: aliceToken}, 5*time.Second).Result()
+	if _, ok := result.(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected SuccessResponse for logout, got %T", result)
+	}
+	result, _ = system.Root.RequestFuture(enginePID, &pb.PostMessage{
+		Id: "p3", SubredditId: "s1", AuthorId: "alice", Token: aliceToken,
+	}, 5*time.Second).Result()
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected a logged-out token to be rejected, got %T", result)
+	}
+}
+
+func TestVotesCreditAuthorKarmaOnProfile(t *testing.T) {
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	aliceToken := registerAndLogin(t, system.Root, enginePID, "alice")
+	bobToken := registerAndLogin(t, system.Root, enginePID, "bob")
+	createSubreddit(t, system.Root, enginePID, aliceToken, "alice", "s1")
+
+	for _, msg := range []interface{}{
+		&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: aliceToken},
+		&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Token: aliceToken},
+		&pb.VoteMessage{TargetId: "p1", UserId: "bob", IsUpvote: true, Token: bobToken},
+		&pb.VoteMessage{TargetId: "c1", UserId: "bob", IsUpvote: false, Token: bobToken},
+	} {
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+
+	result, err := system.Root.RequestFuture(enginePID, &pb.GetUserProfileMessage{UserId: "alice"}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to get response from engine actor: %v", err)
+	}
+	profile, ok := result.(*pb.UserProfileResponse)
+	if !ok {
+		t.Fatalf("Expected UserProfileResponse, got %T", result)
+	}
+	if profile.PostKarma != 1 || profile.CommentKarma != -1 || profile.TotalKarma != 0 {
+		t.Errorf("Expected karma 1/-1/0, got %d/%d/%d", profile.PostKarma, profile.CommentKarma, profile.TotalKarma)
+	}
+
+	result, _ = system.Root.RequestFuture(enginePID, &pb.GetCommentsMessage{PostId: "p1"}, 5*time.Second).Result()
+	comments, ok := result.(*pb.CommentsResponse)
+	if !ok || len(comments.Comments) != 1 {
+		t.Fatalf("Expected one comment, got %v", result)
+	}
+	if comments.Comments[0].Score != -1 {
+		t.Errorf("Expected comment score -1, got %d", comments.Comments[0].Score)
+	}
+
+	result, _ = system.Root.RequestFuture(enginePID, &pb.GetUserProfileMessage{UserId: "nobody"}, 5*time.Second).Result()
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse for an unknown user, got %T", result)
+	}
+}
+
+func TestVoteMessageClear(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	token := registerAndLogin(t, system.Root, enginePID, "alice")
+	createSubreddit(t, system.Root, enginePID, token, "alice", "s1")
+
+	post := &pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: token}
+	if _, err := system.Root.RequestFuture(enginePID, post, 5*time.Second).Result(); err != nil {
+		t.Fatalf("Failed to get response from engine actor: %v", err)
+	}
+
+	for _, msg := range []*pb.VoteMessage{
+		{TargetId: "p1", UserId: "alice", IsUpvote: true, Token: token},
+		{TargetId: "p1", UserId: "alice", IsUpvote: true, Token: token},
+		{TargetId: "p1", UserId: "alice", Clear: true, Token: token},
+	} {
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse, got %v", result)
+		}
+		stored, err := store.GetPost("p1")
+		if err != nil {
+			t.Fatalf("Failed to get post: %v", err)
+		}
+		want := int32(1)
+		if msg.Clear {
+			want = 0
+		}
+		if stored.Karma != want {
+			t.Errorf("Expected karma %d after %v, got %d", want, msg, stored.Karma)
+		}
+	}
+}
+
+func TestGetFeedSortAndLimit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics(), WithClock(func() time.Time { return now }))
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	for i, karma := range []int32{5, 50, 1} {
+		store.CreatePost(&models.Post{
+			ID:          fmt.Sprintf("p%d", i),
+			SubredditID: "s1",
+			Karma:       karma,
+			Created:     now.Add(-time.Duration(i) * 48 * time.Hour).Unix(),
+		})
+	}
+
+	tests := []struct {
+		msg  *pb.GetFeedMessage
+		want []string
+	}{
+		{&pb.GetFeedMessage{SubredditIds: []string{"s1"}, Sort: "new"}, []string{"p0", "p1", "p2"}},
+		{&pb.GetFeedMessage{SubredditIds: []string{"s1"}, Sort: "top", Limit: 2}, []string{"p1", "p0"}},
+		{&pb.GetFeedMessage{SubredditIds: []string{"s1"}, Sort: "top", Window: "week", Limit: 1}, []string{"p1"}},
+		{&pb.GetFeedMessage{SubredditIds: []string{"s1"}, Sort: "top", Window: "day"}, []string{"p0"}},
+	}
+	for _, tt := range tests {
+		result, err := system.Root.RequestFuture(enginePID, tt.msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		feed, ok := result.(*pb.FeedResponse)
+		if !ok {
+			t.Fatalf("Expected FeedResponse for %v, got %v", tt.msg, result)
+		}
+		var got []string
+		for _, post := range feed.Posts {
+			got = append(got, post.Id)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.msg, tt.want, got)
+		}
+	}
+
+	result, _ := system.Root.RequestFuture(enginePID, &pb.GetFeedMessage{Sort: "random"}, 5*time.Second).Result()
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse for an unknown sort, got %T", result)
+	}
+}
+
+func TestHomeAndAllFeeds(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics(), WithClock(func() time.Time { return now }))
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	store.CreateSubreddit(&models.Subreddit{ID: "s1", Name: "s1", Members: map[string]bool{"u1": true}})
+	store.CreateSubreddit(&models.Subreddit{ID: "s2", Name: "s2", Members: map[string]bool{"u1": true}})
+	store.CreateSubreddit(&models.Subreddit{ID: "s3", Name: "s3", Members: map[string]bool{}})
+	for i, post := range []*models.Post{
+		{ID: "a", SubredditID: "s1", Karma: 3},
+		{ID: "b", SubredditID: "s2", Karma: 0},
+		{ID: "c", SubredditID: "s3", Karma: 7},
+		{ID: "d", SubredditID: "s2", Karma: 9, Removed: true},
+	} {
+		post.Created = now.Add(-time.Duration(i) * time.Hour).Unix()
+		store.CreatePost(post)
+	}
+
+	tests := []struct {
+		msg  interface{}
+		want []string
+	}{
+		{&pb.GetHomeFeedMessage{UserId: "u1", Sort: "new"}, []string{"a", "b"}},
+		{&pb.GetHomeFeedMessage{UserId: "u2"}, nil},
+		{&pb.GetAllFeedMessage{Sort: "top"}, []string{"c", "a", "b"}},
+		{&pb.GetAllFeedMessage{Popular: true, Sort: "new", Limit: 1}, []string{"a"}},
+		{&pb.GetAllFeedMessage{Popular: true, Sort: "top"}, []string{"c", "a"}},
+	}
+	for _, tt := range tests {
+		result, err := system.Root.RequestFuture(enginePID, tt.msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		feed, ok := result.(*pb.FeedResponse)
+		if !ok {
+			t.Fatalf("Expected FeedResponse for %v, got %v", tt.msg, result)
+		}
+		var got []string
+		for _, post := range feed.Posts {
+			got = append(got, post.Id)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.msg, tt.want, got)
+		}
+	}
+}
+
+func TestMembership(t *testing.T) {
+	engine := newTestEngine(t, memory.NewMemoryStore())
+
+	members := func() float64 {
+		return testutil.ToFloat64(engine.metrics.SubredditSize.WithLabelValues("membership-s1"))
+	}
+
+	alice := engine.registerAndLogin("alice")
+	bob := engine.registerAndLogin("bob")
+	engine.request(&pb.SubredditMessage{Id: "membership-s1", Name: "membership", CreatorId: "alice", Token: alice})
+	if got := members(); got != 0 {
+		t.Errorf("Expected a new subreddit to have 0 members, got %v", got)
+	}
+
+	// Joining twice is the same as joining once.
+	for _, msg := range []interface{}{
+		&pb.JoinSubredditMessage{SubredditId: "membership-s1", UserId: "alice", Token: alice},
+		&pb.JoinSubredditMessage{SubredditId: "membership-s1", UserId: "alice", Token: alice},
+		&pb.JoinSubredditMessage{SubredditId: "membership-s1", UserId: "bob", Token: bob},
+	} {
+		if _, ok := engine.request(msg).(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %v", msg)
+		}
+	}
+	if got := members(); got != 2 {
+		t.Errorf("Expected 2 members, got %v", got)
+	}
+
+	page, ok := engine.request(&pb.GetSubredditMembersMessage{SubredditId: "membership-s1", Limit: 1}).(*pb.SubredditMembersResponse)
+	if !ok || fmt.Sprint(page.UserIds) != "[alice]" || page.Total != 2 || page.NextCursor == "" {
+		t.Fatalf("Expected alice on the first page of 2, got %v", page)
+	}
+	page = engine.request(&pb.GetSubredditMembersMessage{SubredditId: "membership-s1", After: page.NextCursor}).(*pb.SubredditMembersResponse)
+	if fmt.Sprint(page.UserIds) != "[bob]" {
+		t.Errorf("Expected bob on the second page, got %v", page.UserIds)
+	}
+
+	subscriptions := engine.request(&pb.GetSubscriptionsMessage{UserId: "bob"}).(*pb.SubredditsResponse)
+	if len(subscriptions.Subreddits) != 1 || subscriptions.Subreddits[0].Members != 2 {
+		t.Errorf("Expected bob's one subscription with 2 members, got %v", subscriptions.Subreddits)
+	}
+
+	for i := 0; i < 2; i++ {
+		engine.request(&pb.LeaveSubredditMessage{SubredditId: "membership-s1", UserId: "bob", Token: bob})
+	}
+	if got := members(); got != 1 {
+		t.Errorf("Expected 1 member after bob left, got %v", got)
+	}
+	if got := strings.Count(strings.Join(engine.events.Types(), ","), "subreddit_"); got != 4 {
+		t.Errorf("Expected one create, two joins and one leave, got %v", engine.events.Types())
+	}
+	if _, ok := engine.request(&pb.GetSubredditMembersMessage{SubredditId: "missing"}).(*pb.ErrorResponse); !ok {
+		t.Error("Expected ErrorResponse for an unknown subreddit")
+	}
+}
+
+// joiningStore lets another user join a subreddit along with every join, as
+// a writer the engine does not know about would.
+type joiningStore struct {
+	store.Store
+	other string
+}
+
+func (s *joiningStore) JoinSubreddit(subredditID, userID string) error {
+	if err := s.Store.JoinSubreddit(subredditID, s.other); err != nil {
+		return err
+	}
+	return s.Store.JoinSubreddit(subredditID, userID)
+}
+
+func TestMemberGaugeCountsStoredMembers(t *testing.T) {
+	system := actor.NewActorSystem()
+	m := metrics.NewRedditMetrics()
+	s := &joiningStore{Store: memory.NewMemoryStore(), other: "carol"}
+	engine := NewEngineActor(s, m)
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	alice := registerAndLogin(t, system.Root, enginePID, "alice")
+	createSubreddit(t, system.Root, enginePID, alice, "alice", "gauge-s1")
+	result, err := system.Root.RequestFuture(enginePID, &pb.JoinSubredditMessage{SubredditId: "gauge-s1", UserId: "alice", Token: alice}, 5*time.Second).Result()
+	if _, ok := result.(*pb.SuccessResponse); err != nil || !ok {
+		t.Fatalf("Expected alice to join, got %v (%v)", result, err)
+	}
+	if got := testutil.ToFloat64(m.SubredditSize.WithLabelValues("gauge-s1")); got != 2 {
+		t.Errorf("Expected the gauge to count both stored members, got %v", got)
+	}
+}
+
+// blockingStore holds CreatePost calls for one subreddit until release is
+// closed.
+type blockingStore struct {
+	store.Store
+	subredditID string
+	blocked     chan struct{}
+	release     chan struct{}
+}
+
+func (b *blockingStore) CreatePost(post *models.Post) error {
+	if post.SubredditID == b.subredditID {
+		close(b.blocked)
+		<-b.release
+	}
+	return b.Store.CreatePost(post)
+}
+
+func TestBusySubredditDoesNotBlockOtherRequests(t *testing.T) {
+	system := actor.NewActorSystem()
+	slow := &blockingStore{
+		Store:       memory.NewMemoryStore(),
+		subredditID: "slow",
+		blocked:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	engine := NewEngineActor(slow, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	token := registerAndLogin(t, system.Root, enginePID, "user1")
+	slow.CreateSubreddit(&models.Subreddit{ID: "slow", Name: "slow", Members: map[string]bool{"user1": true}})
+
+	postFuture := system.Root.RequestFuture(enginePID, &pb.PostMessage{
+		Id: "post1", SubredditId: "slow", AuthorId: "user1", Title: "stuck", Token: token,
+	}, 5*time.Second)
+	<-slow.blocked
+
+	// Registrations, DMs and other subreddits are answered while "slow" is busy.
+	registerAndLogin(t, system.Root, enginePID, "user2")
+	requests := []interface{}{
+		&pb.DirectMessageMessage{Id: "dm1", FromId: "user1", ToId: "user2", Content: "hi", Token: token},
+		&pb.SubredditMessage{Id: "fast", Name: "fast", CreatorId: "user1", Token: token},
+	}
+	for _, msg := range requests {
+		result, err := system.Root.RequestFuture(enginePID, msg, time.Second).Result()
+		if err != nil {
+			t.Fatalf("Expected %T to be answered while a subreddit is busy: %v", msg, err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Errorf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+
+	close(slow.release)
+	result, err := postFuture.Result()
+	if err != nil {
+		t.Fatalf("Failed to get response for the held post: %v", err)
+	}
+	if _, ok := result.(*pb.SuccessResponse); !ok {
+		t.Errorf("Expected SuccessResponse for the held post, got %v", result)
+	}
+}
+
+func TestVotesAndCommentsRouteThroughPostSubreddit(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	token := registerAndLogin(t, system.Root, enginePID, "user1")
+	store.CreatePost(&models.Post{ID: "post1", SubredditID: "s1", AuthorID: "user1"})
+
+	requests := []interface{}{
+		&pb.CommentMessage{Id: "c1", PostId: "post1", AuthorId: "user1", Content: "first", Token: token},
+		&pb.VoteMessage{TargetId: "c1", UserId: "user1", IsUpvote: true, Token: token},
+		&pb.VoteMessage{TargetId: "post1", UserId: "user1", IsUpvote: true, Token: token},
+	}
+	for _, msg := range requests {
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response for %T: %v", msg, err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Errorf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+}
+
+func TestEngineEmitsDomainEvents(t *testing.T) {
+	system := actor.NewActorSystem()
+	recorder := events.NewRecorder()
+	events.Attach(system.EventStream, recorder)
+	store := memory.NewMemoryStore()
+	store.CreateUser(&models.User{ID: "bob", Username: "bob"})
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	token := registerAndLogin(t, system.Root, enginePID, "alice")
+	for _, msg := range []interface{}{
+		&pb.SubredditMessage{Id: "s1", Name: "s1", CreatorId: "alice", Token: token},
+		&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "alice", Token: token},
+		&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: token},
+		// A failed write publishes nothing.
+		&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: token},
+		&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Token: token},
+		&pb.VoteMessage{TargetId: "p1", UserId: "alice", IsUpvote: true, Token: token},
+		&pb.VoteMessage{TargetId: "p1", UserId: "alice", Clear: true, Token: token},
+		&pb.DirectMessageMessage{Id: "m1", FromId: "alice", ToId: "bob", Token: token},
+		&pb.LeaveSubredditMessage{SubredditId: "s1", UserId: "alice", Token: token},
+	} {
+		if _, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result(); err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+	}
+
+	want := "user_registered,subreddit_created,subreddit_joined,post_created,comment_added,vote_cast,vote_cast,message_sent,subreddit_left"
+	if got := strings.Join(recorder.Types(), ","); got != want {
+		t.Errorf("Expected events %s, got %s", want, got)
+	}
+	recorded := recorder.Events()
+	if cleared, ok := recorded[6].(*events.VoteCast); !ok || !cleared.Cleared {
+		t.Errorf("Expected a cleared vote, got %+v", recorded[6])
+	}
+}
+
+func TestSearchFindsNewContent(t *testing.T) {
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	token := registerAndLogin(t, system.Root, enginePID, "alice")
+	for _, msg := range []interface{}{
+		&pb.SubredditMessage{Id: "garden", Name: "gardening", Description: "Growing tomatoes", CreatorId: "alice", Token: token},
+		&pb.PostMessage{Id: "p1", SubredditId: "garden", AuthorId: "alice", Title: "Tomato blight", Token: token},
+		&pb.PostMessage{Id: "p2", SubredditId: "garden", AuthorId: "alice", Title: "Cucumbers", Token: token},
+		&pb.CommentMessage{Id: "c1", PostId: "p2", AuthorId: "alice", Content: "Better than tomatoes", Token: token},
+	} {
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+
+	search := func(msg *pb.SearchMessage) *pb.SearchResponse {
+		t.Helper()
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		response, ok := result.(*pb.SearchResponse)
+		if !ok {
+			t.Fatalf("Expected SearchResponse, got %v", result)
+		}
+		return response
+	}
+
+	first := search(&pb.SearchMessage{Query: "tomatoes", Limit: 2})
+	if len(first.Results) != 2 || first.NextCursor == "" {
+		t.Fatalf("Expected a first page of 2 with a next cursor, got %v", first)
+	}
+	rest := search(&pb.SearchMessage{Query: "tomatoes", Limit: 2, After: first.NextCursor})
+	if len(rest.Results) != 1 || rest.NextCursor != "" {
+		t.Fatalf("Expected a last page of 1, got %v", rest)
+	}
+	seen := map[string]bool{}
+	for _, result := range append(first.Results, rest.Results...) {
+		seen[result.Kind+":"+result.Id] = true
+	}
+	if !seen["post:p1"] || !seen["comment:c1"] || !seen["subreddit:garden"] {
+		t.Errorf("Expected the post, comment and subreddit, got %v", seen)
+	}
+
+	comments := search(&pb.SearchMessage{Query: "tomato", Kinds: []string{"comment"}, SubredditId: "garden"})
+	if len(comments.Results) != 1 || comments.Results[0].PostId != "p2" {
+		t.Errorf("Expected the comment on p2, got %v", comments.Results)
+	}
+
+	result, _ := system.Root.RequestFuture(enginePID, &pb.SearchMessage{}, 5*time.Second).Result()
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse for an empty query, got %v", result)
+	}
+}
+
+func TestSearchFindsContentAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	start := func() (*memory.DurableStore, *actor.RootContext, *actor.PID) {
+		store, err := memory.OpenDurableStore(dir, memory.DurableOptions{})
+		if err != nil {
+			t.Fatalf("Failed to open store: %v", err)
+		}
+		system := actor.NewActorSystem()
+		engine := NewEngineActor(store, metrics.NewRedditMetrics())
+		return store, system.Root, system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+	}
+
+	store, root, enginePID := start()
+	token := registerAndLogin(t, root, enginePID, "alice")
+	for _, msg := range []interface{}{
+		&pb.SubredditMessage{Id: "garden", Name: "gardening", CreatorId: "alice", Token: token},
+		&pb.PostMessage{Id: "p1", SubredditId: "garden", AuthorId: "alice", Title: "Tomato blight", Token: token},
+		&pb.PostMessage{Id: "p2", SubredditId: "garden", AuthorId: "alice", Title: "Tomato soup", Token: token},
+		&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Content: "Tomatoes again", Token: token},
+		&pb.DeletePostMessage{PostId: "p2", AuthorId: "alice", Token: token},
+	} {
+		result, err := root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+	store.Close()
+
+	store, root, enginePID = start()
+	defer store.Close()
+	result, err := root.RequestFuture(enginePID, &pb.SearchMessage{Query: "tomato", SubredditId: "garden"}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to get response from engine actor: %v", err)
+	}
+	response, ok := result.(*pb.SearchResponse)
+	if !ok {
+		t.Fatalf("Expected SearchResponse, got %v", result)
+	}
+	var found []string
+	for _, result := range response.Results {
+		found = append(found, result.Kind+":"+result.Id)
+	}
+	sort.Strings(found)
+	if strings.Join(found, ",") != "comment:c1,post:p1" {
+		t.Errorf("Expected p1 and its comment, but not the deleted p2, got %v", found)
+	}
+}
@@ -0,0 +1,92 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/ranking"
+	"reddit-clone/internal/search"
+	"reddit-clone/internal/store"
+)
+
+// requestError is an error found by the engine's own checks of a request. It
+// matches kind, one of the store sentinels, but keeps its own message.
+type requestError struct {
+	kind    error
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+func (e *requestError) Unwrap() error { return e.kind }
+
+func invalidf(format string, args ...interface{}) error {
+	return &requestError{kind: store.ErrInvalid, message: fmt.Sprintf(format, args...)}
+}
+
+func forbiddenf(format string, args ...interface{}) error {
+	return &requestError{kind: store.ErrForbidden, message: fmt.Sprintf(format, args...)}
+}
+
+// answerError is the ErrorResponse another grain answered a request with.
+type answerError struct {
+	resp *pb.ErrorResponse
+}
+
+func (e *answerError) Error() string { return e.resp.Error }
+
+// errorCode classifies err. Errors that match none of the known sentinels
+// are failures of the engine or its store, not of the request.
+func errorCode(err error) pb.ErrorCode {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return pb.ErrorCode_NOT_FOUND
+	case errors.Is(err, store.ErrAlreadyExists):
+		return pb.ErrorCode_ALREADY_EXISTS
+	case errors.Is(err, store.ErrForbidden):
+		return pb.ErrorCode_FORBIDDEN
+	case errors.Is(err, auth.ErrInvalidCredentials), errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrTokenExpired), errors.Is(err, auth.ErrTokenRevoked):
+		return pb.ErrorCode_UNAUTHENTICATED
+	case errors.Is(err, store.ErrInvalid),
+		errors.Is(err, pagination.ErrInvalidCursor), errors.Is(err, pagination.ErrBothCursors),
+		errors.Is(err, ranking.ErrUnknownSort), errors.Is(err, ranking.ErrUnknownWindow),
+		errors.Is(err, search.ErrEmptyQuery), errors.Is(err, search.ErrUnknownKind):
+		return pb.ErrorCode_INVALID
+	default:
+		return pb.ErrorCode_INTERNAL
+	}
+}
+
+// errorResponse describes err to a client: its message, its code and what
+// it refers to, if anything.
+func errorResponse(err error) *pb.ErrorResponse {
+	resp := &pb.ErrorResponse{Error: err.Error(), Code: errorCode(err)}
+	var notFound *store.NotFoundError
+	var reference *store.ReferenceError
+	switch {
+	case errors.As(err, &notFound):
+		resp.Details = map[string]string{"kind": notFound.Kind, "id": notFound.ID}
+	case errors.As(err, &reference):
+		resp.Details = map[string]string{"field": reference.Field, "id": reference.ID}
+	}
+	return resp
+}
+
+// fail answers the request being handled with err and counts it by code.
+// An answerError is passed on as it is.
+func (e *engineCore) fail(context actor.Context, err error) {
+	var answered *answerError
+	if errors.As(err, &answered) {
+		// The grain that answered counted it already.
+		context.Respond(answered.resp)
+		return
+	}
+	resp := errorResponse(err)
+	e.metrics.RecordErrorCode(resp.Code.String())
+	context.Respond(resp)
+}
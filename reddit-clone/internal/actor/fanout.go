@@ -0,0 +1,93 @@
+package actor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+)
+
+// askTimeout bounds how long an actor of the engine waits for the answer of
+// another grain.
+const askTimeout = 5 * time.Second
+
+// ask sends msg to the grain of kind that owns identity and waits for its
+// answer. An ErrorResponse is returned as an *answerError. Only user grains ask subreddit
+// grains, which never wait for an answer themselves, so two grains never
+// wait on each other.
+func (e *engineCore) ask(context actor.Context, kind, identity string, msg interface{}) (interface{}, error) {
+	answers, err := e.askEach(context, kind, []string{identity}, func(string) interface{} { return msg })
+	if err != nil {
+		return nil, err
+	}
+	return answers[0], nil
+}
+
+// askEach is ask for several grains of kind at once. It returns their answers
+// in the order of identities.
+func (e *engineCore) askEach(context actor.Context, kind string, identities []string, request func(identity string) interface{}) ([]interface{}, error) {
+	pids := make([]*actor.PID, len(identities))
+	for i, identity := range identities {
+		pids[i] = e.owner(context, kind, identity)
+		if pids[i] == nil {
+			return nil, fmt.Errorf("no actor owns %s %s", kind, identity)
+		}
+	}
+	return succeeded(askAll(context, pids, func(i int) interface{} { return request(identities[i]) }))
+}
+
+// askOtherMembers sends msg to the member actor of every other cluster
+// member, for the parts of a request their stores hold, and returns their
+// answers. Outside a cluster there are none.
+func (e *engineCore) askOtherMembers(context actor.Context, msg interface{}) ([]interface{}, error) {
+	return succeeded(askAll(context, e.otherMembers(), func(int) interface{} { return msg }))
+}
+
+// otherMembers returns the member actors of the other cluster members.
+func (e *engineCore) otherMembers() []*actor.PID {
+	if e.cluster == nil {
+		return nil
+	}
+	self := e.cluster.ActorSystem.Address()
+	var pids []*actor.PID
+	for _, address := range e.members.list() {
+		if address != self {
+			pids = append(pids, actor.NewPID(address, memberName))
+		}
+	}
+	return pids
+}
+
+// askAll sends each of pids its request at once and waits for every answer,
+// which may be an ErrorResponse.
+func askAll(context actor.Context, pids []*actor.PID, request func(i int) interface{}) ([]interface{}, error) {
+	futures := make([]*actor.Future, len(pids))
+	for i, pid := range pids {
+		futures[i] = context.RequestFuture(pid, request(i), askTimeout)
+	}
+	answers := make([]interface{}, len(futures))
+	for i, future := range futures {
+		answer, err := future.Result()
+		if err != nil {
+			return nil, fmt.Errorf("asking %s: %w", pids[i].Id, err)
+		}
+		answers[i] = answer
+	}
+	return answers, nil
+}
+
+// succeeded returns the first ErrorResponse among answers as an
+// *answerError.
+func succeeded(answers []interface{}, err error) ([]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		if resp, failed := answer.(*pb.ErrorResponse); failed {
+			return nil, &answerError{resp: resp}
+		}
+	}
+	return answers, nil
+}
@@ -0,0 +1,210 @@
+package actor
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/clusterproviders/automanaged"
+	"github.com/asynkron/protoactor-go/cluster/identitylookup/disthash"
+	"github.com/asynkron/protoactor-go/remote"
+
+	pb "reddit-clone/api/proto/generated"
+)
+
+// Cluster kinds of the engine's grains. A grain's identity is the ID of the
+// user or subreddit it owns.
+const (
+	UserKind      = "user"
+	SubredditKind = "subreddit"
+)
+
+// GrainFor returns the grain that owns msg. ok is false for requests without
+// a single owner, such as feeds over several subreddits, and for comment,
+// vote, moderation and edit requests that do not name their subreddit. identity is
+// empty when msg leaves the owning ID unset.
+func GrainFor(msg interface{}) (kind, identity string, ok bool) {
+	// Getters keep a nil request from panicking.
+	switch msg := msg.(type) {
+	case *pb.UserMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.LoginMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.GetUserProfileMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.UpdateProfileMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.ChangePasswordMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.DeleteAccountMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.ExportUserDataMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.DirectMessageMessage:
+		// Messages are stored in the recipient's inbox.
+		return UserKind, msg.GetToId(), true
+	case *pb.MessageCopyMessage:
+		return UserKind, msg.GetMessage().GetFromId(), true
+	case *pb.GetDirectMessagesMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.GetConversationsMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.MarkReadMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.SubscribeMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.UnsubscribeMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.Notification:
+		return UserKind, msg.GetUserId(), true
+	case *pb.KarmaMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.GetSubscriptionsMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.GetHomeFeedMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.SubscriptionMessage:
+		return UserKind, msg.GetUserId(), true
+	case *pb.SubredditMessage:
+		return SubredditKind, msg.GetId(), true
+	case *pb.GetSubredditMessage:
+		if msg.GetId() != "" {
+			return SubredditKind, msg.GetId(), true
+		}
+	case *pb.JoinSubredditMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.LeaveSubredditMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.GetSubredditMembersMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.PostMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.CommentMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.GetCommentsMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.VoteMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.AddModeratorMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.BanUserMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.GetModLogMessage:
+		return SubredditKind, msg.GetSubredditId(), true
+	case *pb.RemoveContentMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.LockPostMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.EditPostMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.EditCommentMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.DeletePostMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.DeleteCommentMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.GetEditHistoryMessage:
+		if msg.GetSubredditId() != "" {
+			return SubredditKind, msg.GetSubredditId(), true
+		}
+	case *pb.GetFeedMessage:
+		if len(msg.GetSubredditIds()) == 1 {
+			return SubredditKind, msg.GetSubredditIds()[0], true
+		}
+	}
+	return "", "", false
+}
+
+// newGrain creates the actor that owns identity. Cluster activations pass an
+// empty identity and learn it from cluster.ClusterInit.
+func (e *engineCore) newGrain(kind, identity string) actor.Actor {
+	if kind == UserKind {
+		return &UserActor{engineCore: e, userID: identity}
+	}
+	return &SubredditActor{engineCore: e, subredditID: identity}
+}
+
+// ClusterConfig describes how an engine node or simulator joins a cluster.
+type ClusterConfig struct {
+	Name string
+	// Host and Port are this node's remoting address. Port 0 picks a free port.
+	Host string
+	Port int
+	// ManagePort serves membership for the static provider, and Peers lists
+	// the host:ManagePort of every member, including this one.
+	ManagePort int
+	Peers      []string
+	// Provider replaces the static provider, e.g. with an in-memory one in
+	// tests.
+	Provider cluster.ClusterProvider
+}
+
+func (c ClusterConfig) build(kinds ...*cluster.Kind) *cluster.Config {
+	provider := c.Provider
+	if provider == nil {
+		provider = automanaged.NewWithConfig(2*time.Second, c.ManagePort, c.Peers...)
+	}
+	return cluster.Configure(c.Name, provider, disthash.New(), remote.Configure(c.Host, c.Port),
+		cluster.WithKinds(kinds...))
+}
+
+// StartClusterMember joins the cluster as a member hosting user and subreddit
+// grains, which keep their data in the engine's store, and makes the engine
+// route requests to grains anywhere in the cluster instead of to local
+// children. Each member stores the users and subreddits whose grains it
+// hosts; grains send what concerns another grain to it, and ask the other
+// members for requests that span every store. It also spawns the member
+// actor, which answers for this member's store. Call it before spawning the
+// engine.
+func (e *EngineActor) StartClusterMember(system *actor.ActorSystem, config ClusterConfig) *cluster.Cluster {
+	kinds := make([]*cluster.Kind, 0, 2)
+	for _, kind := range []string{UserKind, SubredditKind} {
+		kind := kind
+		kinds = append(kinds, cluster.NewKind(kind, actor.PropsFromProducer(func() actor.Actor {
+			return e.newGrain(kind, "")
+		})))
+	}
+
+	e.cluster = cluster.New(system, config.build(kinds...))
+	e.members = &memberSet{}
+	system.EventStream.Subscribe(func(event interface{}) {
+		if topology, ok := event.(*cluster.ClusterTopology); ok {
+			e.members.update(topology)
+		}
+	})
+	local := *e.engineCore
+	local.cluster, local.members = nil, nil
+	if _, err := system.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor {
+		return &memberActor{engineCore: &local}
+	}), memberName); err != nil {
+		panic("actor: failed to spawn the member actor: " + err.Error())
+	}
+	e.cluster.StartMember()
+	return e.cluster
+}
+
+// StartClusterClient joins the cluster without hosting grains, for processes
+// such as the simulator that only send requests.
+func StartClusterClient(system *actor.ActorSystem, config ClusterConfig) *cluster.Cluster {
+	c := cluster.New(system, config.build())
+	c.StartClient()
+	return c
+}
@@ -0,0 +1,494 @@
+package actor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+func TestGrainFor(t *testing.T) {
+	tests := []struct {
+		msg      interface{}
+		kind     string
+		identity string
+		ok       bool
+	}{
+		{&pb.LoginMessage{UserId: "u1"}, UserKind, "u1", true},
+		{&pb.DirectMessageMessage{FromId: "u1", ToId: "u2"}, UserKind, "u2", true},
+		{&pb.MessageCopyMessage{Message: &pb.DirectMessageMessage{FromId: "u1", ToId: "u2"}}, UserKind, "u1", true},
+		{&pb.Notification{UserId: "u1", ActorId: "u2"}, UserKind, "u1", true},
+		{&pb.KarmaMessage{UserId: "u1", VoterId: "u2"}, UserKind, "u1", true},
+		{&pb.GetConversationsMessage{UserId: "u1"}, UserKind, "u1", true},
+		{&pb.MarkReadMessage{UserId: "u1", WithId: "u2"}, UserKind, "u1", true},
+		{&pb.DeleteAccountMessage{UserId: "u1"}, UserKind, "u1", true},
+		{&pb.PostMessage{SubredditId: "s1"}, SubredditKind, "s1", true},
+		{&pb.VoteMessage{TargetId: "p1", SubredditId: "s1"}, SubredditKind, "s1", true},
+		{&pb.VoteMessage{TargetId: "p1"}, "", "", false},
+		{&pb.BanUserMessage{SubredditId: "s1", UserId: "u1"}, SubredditKind, "s1", true},
+		{&pb.RemoveContentMessage{TargetId: "c1", SubredditId: "s1"}, SubredditKind, "s1", true},
+		{&pb.LockPostMessage{PostId: "p1"}, "", "", false},
+		{&pb.EditCommentMessage{CommentId: "c1", SubredditId: "s1"}, SubredditKind, "s1", true},
+		{&pb.DeletePostMessage{PostId: "p1"}, "", "", false},
+		{&pb.GetFeedMessage{SubredditIds: []string{"s1", "s2"}}, "", "", false},
+		{&pb.GetHomeFeedMessage{UserId: "u1"}, UserKind, "u1", true},
+		{&pb.GetSubredditMessage{Id: "s1"}, SubredditKind, "s1", true},
+		{&pb.GetSubredditMessage{Name: "golang"}, "", "", false},
+		{&pb.GetSubredditMembersMessage{SubredditId: "s1"}, SubredditKind, "s1", true},
+		{&pb.GetSubscriptionsMessage{UserId: "u1"}, UserKind, "u1", true},
+		{&pb.SubscriptionMessage{UserId: "u1", SubredditId: "s1"}, UserKind, "u1", true},
+		{(*pb.PostMessage)(nil), SubredditKind, "", true},
+	}
+	for _, tt := range tests {
+		kind, identity, ok := GrainFor(tt.msg)
+		if kind != tt.kind || identity != tt.identity || ok != tt.ok {
+			t.Errorf("GrainFor(%T %v) = %q, %q, %v; want %q, %q, %v", tt.msg, tt.msg, kind, identity, ok, tt.kind, tt.identity, tt.ok)
+		}
+	}
+}
+
+// memberEnv holds the JSON ClusterConfig of a test member running as a child
+// process; see startTestCluster.
+const memberEnv = "REDDIT_TEST_CLUSTER_MEMBER"
+
+// testEngineName names the engine of a child member, so the tests can send
+// it requests.
+const testEngineName = "engine"
+
+func TestMain(m *testing.M) {
+	if config := os.Getenv(memberEnv); config != "" {
+		runTestMember(config)
+		return
+	}
+	code := m.Run()
+	if sharedCluster != nil {
+		sharedCluster.stop()
+	}
+	os.Exit(code)
+}
+
+// testCluster is a two-member cluster shared by the cluster tests, which use
+// their own user and subreddit IDs. Each member has its own memory store and
+// engine; they share a token secret. The second member runs in a child
+// process: protoactor's remote sets gRPC's global logger whenever a member
+// starts, which races with the gRPC goroutines of any member already running
+// in the same process.
+type testCluster struct {
+	store     *memory.MemoryStore // the first member's
+	root      *actor.RootContext
+	cluster   *cluster.Cluster
+	engines   []*actor.PID
+	addresses []string
+	child     *exec.Cmd
+	stdin     io.WriteCloser
+}
+
+var (
+	clusterOnce   sync.Once
+	sharedCluster *testCluster
+	clusterErr    error
+)
+
+func testClusterTokens() *auth.TokenManager {
+	return auth.NewTokenManager([]byte("cluster-secret"), time.Hour)
+}
+
+// startTestCluster returns the shared cluster, starting it on first use.
+func startTestCluster(t *testing.T) *testCluster {
+	t.Helper()
+	clusterOnce.Do(func() {
+		sharedCluster, clusterErr = newTestCluster()
+	})
+	if clusterErr != nil {
+		t.Fatalf("Failed to start the test cluster: %v", clusterErr)
+	}
+	return sharedCluster
+}
+
+func newTestCluster() (*testCluster, error) {
+	ports := make([]int, 4)
+	for i := range ports {
+		lis, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			return nil, err
+		}
+		ports[i] = lis.Addr().(*net.TCPAddr).Port
+		lis.Close()
+	}
+	peers := []string{fmt.Sprintf("localhost:%d", ports[2]), fmt.Sprintf("localhost:%d", ports[3])}
+	configs := make([]ClusterConfig, 2)
+	for i := range configs {
+		configs[i] = ClusterConfig{Name: "test", Host: "localhost", Port: ports[i], ManagePort: ports[2+i], Peers: peers}
+	}
+
+	encoded, err := json.Marshal(configs[1])
+	if err != nil {
+		return nil, err
+	}
+	c := &testCluster{child: exec.Command(os.Args[0], "-test.run=^$")}
+	c.child.Env = append(os.Environ(), memberEnv+"="+string(encoded))
+	if c.stdin, err = c.child.StdinPipe(); err != nil {
+		return nil, err
+	}
+	if err := c.child.Start(); err != nil {
+		return nil, err
+	}
+
+	system := actor.NewActorSystem()
+	topologies := make(chan *cluster.ClusterTopology, 16)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if topology, ok := evt.(*cluster.ClusterTopology); ok {
+			select {
+			case topologies <- topology:
+			default:
+			}
+		}
+	})
+	c.store = memory.NewMemoryStore()
+	engine := NewEngineActor(c.store, metrics.NewRedditMetrics(), WithTokenManager(testClusterTokens()))
+	c.cluster = engine.StartClusterMember(system, configs[0])
+	c.root = system.Root
+	c.engines = []*actor.PID{system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for len(c.addresses) < 2 {
+		select {
+		case topology := <-topologies:
+			c.addresses = []string{system.Address()}
+			for _, member := range topology.Members {
+				if member.Address() != system.Address() {
+					c.addresses = append(c.addresses, member.Address())
+				}
+			}
+		case <-time.After(time.Until(deadline)):
+			c.stop()
+			return nil, fmt.Errorf("the second member did not join")
+		}
+	}
+	c.engines = append(c.engines, actor.NewPID(c.addresses[1], testEngineName))
+
+	// The child's engine answers once it is spawned.
+	for {
+		result, err := c.root.RequestFuture(c.engines[1], &pb.GetUserProfileMessage{UserId: "nobody"}, time.Second).Result()
+		if _, ok := result.(*pb.ErrorResponse); ok && err == nil {
+			return c, nil
+		}
+		if time.Now().After(deadline) {
+			c.stop()
+			return nil, fmt.Errorf("the second member's engine did not answer: %v", err)
+		}
+	}
+}
+
+// runTestMember runs this process as the second member of a test cluster
+// until the parent closes stdin, which it also does by exiting.
+func runTestMember(encoded string) {
+	var config ClusterConfig
+	if err := json.Unmarshal([]byte(encoded), &config); err != nil {
+		log.Fatalf("Invalid %s: %v", memberEnv, err)
+	}
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics(), WithTokenManager(testClusterTokens()))
+	engine.StartClusterMember(system, config)
+	if _, err := system.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor { return engine }), testEngineName); err != nil {
+		log.Fatalf("Failed to spawn the engine: %v", err)
+	}
+	io.Copy(io.Discard, os.Stdin)
+}
+
+func (c *testCluster) stop() {
+	c.stdin.Close()
+	c.child.Wait()
+}
+
+// request sends msg to the engine of member and returns the answer.
+func (c *testCluster) request(t *testing.T, member int, msg interface{}) interface{} {
+	t.Helper()
+	result, err := c.root.RequestFuture(c.engines[member], msg, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to get response for %T: %v", msg, err)
+	}
+	return result
+}
+
+// owner returns the member hosting the grain of kind and identity.
+func (c *testCluster) owner(t *testing.T, kind, identity string) int {
+	t.Helper()
+	if pid := c.cluster.Get(identity, kind); pid != nil {
+		for member, address := range c.addresses {
+			if pid.Address == address {
+				return member
+			}
+		}
+	}
+	t.Fatalf("Found no member hosting %s %s", kind, identity)
+	return 0
+}
+
+func TestClusterPlacesUsersOnOneMember(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a two-member cluster")
+	}
+	c := startTestCluster(t)
+
+	// Every request enters through the first member's engine; the grain
+	// decides which member's store holds the user.
+	const users = 20
+	for i := 0; i < users; i++ {
+		userID := fmt.Sprintf("user%d", i)
+		registerAndLogin(t, c.root, c.engines[0], userID)
+	}
+
+	placed := make([]int, len(c.engines))
+	for i := 0; i < users; i++ {
+		userID := fmt.Sprintf("user%d", i)
+		member := c.owner(t, UserKind, userID)
+		placed[member]++
+		if _, err := c.store.GetUser(userID); (err == nil) != (member == 0) {
+			t.Errorf("Expected %s to be stored only on member %d", userID, member)
+		}
+	}
+	if placed[0] == 0 || placed[1] == 0 {
+		t.Errorf("Expected users to be spread over both members, got %v", placed)
+	}
+
+	// The second member reaches the same grains.
+	result := c.request(t, 1, &pb.GetUserProfileMessage{UserId: "user0"})
+	if profile, ok := result.(*pb.UserProfileResponse); !ok || profile.UserId != "user0" {
+		t.Errorf("Expected the profile of user0, got %v", result)
+	}
+}
+
+func TestClusterRefusesRevokedSessionsOnEveryMember(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a two-member cluster")
+	}
+	c := startTestCluster(t)
+
+	alice := registerAndLogin(t, c.root, c.engines[0], "alice")
+	bob := registerAndLogin(t, c.root, c.engines[0], "bob")
+	const subreddits = 10
+	placed := make([]int, len(c.engines))
+	for i := 0; i < subreddits; i++ {
+		subredditID := fmt.Sprintf("s%d", i)
+		createSubreddit(t, c.root, c.engines[0], bob, "bob", subredditID)
+		placed[c.owner(t, SubredditKind, subredditID)]++
+	}
+	if placed[0] == 0 || placed[1] == 0 {
+		t.Fatalf("Expected subreddits on both members, got %v", placed)
+	}
+
+	// Subreddits on the member that does not store alice ask the one that
+	// does about her session.
+	for i := 0; i < subreddits; i++ {
+		msg := &pb.JoinSubredditMessage{SubredditId: fmt.Sprintf("s%d", i), UserId: "alice", Token: alice}
+		if result, ok := c.request(t, 1, msg).(*pb.SuccessResponse); !ok {
+			t.Errorf("Expected alice to join %s, got %v", msg.SubredditId, result)
+		}
+	}
+
+	if result, ok := c.request(t, 1, &pb.LogoutMessage{Token: alice}).(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected the logout to succeed, got %v", result)
+	}
+	for i := 0; i < subreddits; i++ {
+		msg := &pb.LeaveSubredditMessage{SubredditId: fmt.Sprintf("s%d", i), UserId: "alice", Token: alice}
+		if resp, ok := c.request(t, 0, msg).(*pb.ErrorResponse); !ok || resp.Code != pb.ErrorCode_UNAUTHENTICATED {
+			t.Errorf("Expected the logged-out session to be refused by %s, got %v", msg.SubredditId, resp)
+		}
+	}
+}
+
+// waitFor polls check until it holds, for what the grains send each other
+// without waiting.
+func waitFor(t *testing.T, what string, check func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !check() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func hasPosts(result interface{}, postIDs ...string) bool {
+	feed, ok := result.(*pb.FeedResponse)
+	if !ok {
+		return false
+	}
+	found := make(map[string]bool)
+	for _, post := range feed.Posts {
+		found[post.Id] = true
+	}
+	for _, postID := range postIDs {
+		if !found[postID] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasSubreddits(result interface{}, subredditIDs ...string) bool {
+	response, ok := result.(*pb.SubredditsResponse)
+	if !ok {
+		return false
+	}
+	found := make(map[string]bool)
+	for _, info := range response.Subreddits {
+		found[info.Id] = true
+	}
+	for _, subredditID := range subredditIDs {
+		if !found[subredditID] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestClusterSharesDataAcrossMembers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a two-member cluster")
+	}
+	c := startTestCluster(t)
+
+	carol := registerAndLogin(t, c.root, c.engines[0], "carol")
+	home := c.owner(t, UserKind, "carol")
+	var peer, peerToken string
+	for i := 0; i < 10 && peer == ""; i++ {
+		userID := fmt.Sprintf("peer%d", i)
+		token := registerAndLogin(t, c.root, c.engines[0], userID)
+		if c.owner(t, UserKind, userID) != home {
+			peer, peerToken = userID, token
+		}
+	}
+	if peer == "" {
+		t.Fatal("Expected a user on the member that does not store carol")
+	}
+	// far lives on the other member than carol, near on hers.
+	var far, near string
+	for i := 0; i < 10; i++ {
+		subredditID := fmt.Sprintf("t%d", i)
+		createSubreddit(t, c.root, c.engines[0], peerToken, peer, subredditID)
+		if c.owner(t, SubredditKind, subredditID) == home {
+			near = subredditID
+		} else {
+			far = subredditID
+		}
+	}
+	if far == "" || near == "" {
+		t.Fatalf("Expected subreddits on both members, got far %q and near %q", far, near)
+	}
+
+	for _, msg := range []interface{}{
+		&pb.JoinSubredditMessage{SubredditId: far, UserId: "carol", Token: carol},
+		&pb.JoinSubredditMessage{SubredditId: near, UserId: "carol", Token: carol},
+		&pb.PostMessage{Id: "cluster-p1", SubredditId: far, AuthorId: "carol", Title: "zebra crossing", Token: carol},
+		&pb.PostMessage{Id: "cluster-p2", SubredditId: near, AuthorId: peer, Title: "zebra stripes", Token: peerToken},
+		&pb.VoteMessage{TargetId: "cluster-p1", SubredditId: far, UserId: peer, IsUpvote: true, Token: peerToken},
+	} {
+		if result, ok := c.request(t, 0, msg).(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected %T to succeed, got %v", msg, result)
+		}
+	}
+
+	// Writes reach the user's grain on the other member.
+	waitFor(t, "carol's karma", func() bool {
+		profile, ok := c.request(t, 1, &pb.GetUserProfileMessage{UserId: "carol"}).(*pb.UserProfileResponse)
+		return ok && profile.PostKarma == 1 && profile.TotalKarma == 1
+	})
+	waitFor(t, "carol's subscriptions", func() bool {
+		return hasSubreddits(c.request(t, 1, &pb.GetSubscriptionsMessage{UserId: "carol"}), far, near)
+	})
+	waitFor(t, "carol's home feed", func() bool {
+		return hasPosts(c.request(t, 1, &pb.GetHomeFeedMessage{UserId: "carol"}), "cluster-p1", "cluster-p2")
+	})
+
+	// Reads over several subreddits gather them from both members, through
+	// either engine.
+	for member := range c.engines {
+		if result := c.request(t, member, &pb.GetFeedMessage{SubredditIds: []string{far, near}}); !hasPosts(result, "cluster-p1", "cluster-p2") {
+			t.Errorf("Expected member %d's feed over %s and %s to hold both posts, got %v", member, far, near, result)
+		}
+		if result := c.request(t, member, &pb.GetAllFeedMessage{}); !hasPosts(result, "cluster-p1", "cluster-p2") {
+			t.Errorf("Expected member %d's r/all to hold both posts, got %v", member, result)
+		}
+		waitFor(t, fmt.Sprintf("member %d's search", member), func() bool {
+			response, ok := c.request(t, member, &pb.SearchMessage{Query: "zebra", Kinds: []string{"post"}}).(*pb.SearchResponse)
+			return ok && len(response.Results) == 2
+		})
+		if result := c.request(t, member, &pb.ListSubredditsMessage{}); !hasSubreddits(result, far, near) {
+			t.Errorf("Expected member %d to list %s and %s, got %v", member, far, near, result)
+		}
+		if result := c.request(t, member, &pb.GetTrendingSubredditsMessage{}); !hasSubreddits(result, far, near) {
+			t.Errorf("Expected %s and %s to trend on member %d, got %v", far, near, member, result)
+		}
+		if info, ok := c.request(t, member, &pb.GetSubredditMessage{Name: far}).(*pb.SubredditInfo); !ok || info.Id != far {
+			t.Errorf("Expected member %d to find %s by name, got %v", member, far, info)
+		}
+	}
+
+	// The sender's grain keeps a copy of the message and of its read state.
+	dm := &pb.DirectMessageMessage{Id: "cluster-m1", FromId: "carol", ToId: peer, Content: "hi", Token: carol}
+	if result, ok := c.request(t, 0, dm).(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected the message to be sent, got %v", result)
+	}
+	thread := func() []*pb.DirectMessageMessage {
+		response, ok := c.request(t, 0, &pb.GetDirectMessagesMessage{UserId: "carol", WithId: peer, Token: carol}).(*pb.DirectMessagesResponse)
+		if !ok {
+			return nil
+		}
+		return response.Messages
+	}
+	waitFor(t, "carol's copy of the message", func() bool {
+		messages := thread()
+		return len(messages) == 1 && messages[0].Id == "cluster-m1"
+	})
+	if response, ok := c.request(t, 0, &pb.GetConversationsMessage{UserId: "carol", Token: carol}).(*pb.ConversationsResponse); !ok ||
+		len(response.Conversations) != 1 || response.Conversations[0].OtherId != peer {
+		t.Errorf("Expected carol's conversation with %s, got %v", peer, response)
+	}
+	if result, ok := c.request(t, 0, &pb.MarkReadMessage{UserId: peer, WithId: "carol", Token: peerToken}).(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected the message to be marked read, got %v", result)
+	}
+	waitFor(t, "carol's copy to be read", func() bool {
+		messages := thread()
+		return len(messages) == 1 && messages[0].Read
+	})
+
+	// The export and the deletion reach the post carol keeps on the other
+	// member.
+	export, ok := c.request(t, 0, &pb.ExportUserDataMessage{UserId: "carol", Token: carol}).(*pb.UserDataExport)
+	if !ok {
+		t.Fatalf("Expected carol's export, got %v", export)
+	}
+	var archive userArchive
+	if err := json.Unmarshal(export.Archive, &archive); err != nil {
+		t.Fatalf("Failed to decode the export: %v", err)
+	}
+	if len(archive.Posts) != 1 || archive.Posts[0].ID != "cluster-p1" {
+		t.Errorf("Expected the export to hold cluster-p1, got %v", archive.Posts)
+	}
+	deletion := &pb.DeleteAccountMessage{UserId: "carol", Password: "password123", Token: carol}
+	if result, ok := c.request(t, 0, deletion).(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected the account to be deleted, got %v", result)
+	}
+	feed, ok := c.request(t, 0, &pb.GetFeedMessage{SubredditIds: []string{far}}).(*pb.FeedResponse)
+	if !ok || len(feed.Posts) != 1 || feed.Posts[0].AuthorId != models.DeletedAuthor {
+		t.Errorf("Expected cluster-p1 to be credited to %s, got %v", models.DeletedAuthor, feed)
+	}
+}
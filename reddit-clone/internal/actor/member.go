@@ -0,0 +1,104 @@
+package actor
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
+)
+
+// memberName names the actor each cluster member runs to answer for its own
+// store: session checks of the users stored there, and its part of requests
+// that span every member.
+const memberName = "member"
+
+// memberActor answers from its member's store only. Its engineCore has no
+// cluster, so it never waits on another actor.
+type memberActor struct {
+	*engineCore
+}
+
+func (m *memberActor) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *pb.CheckSessionMessage:
+		m.handleCheckSession(context, msg)
+	case *pb.ExportUserContentMessage:
+		m.handleExportUserContent(context, msg)
+	case *pb.CreditToDeletedMessage:
+		m.handleCreditToDeleted(context, msg)
+	case *pb.SearchMessage:
+		m.handleSearch(context, msg)
+	case *pb.GetAllFeedMessage:
+		m.handleGetAllFeed(context, msg)
+	case *pb.GetSubredditMessage:
+		m.handleGetSubreddit(context, msg)
+	case *pb.ListSubredditsMessage:
+		m.handleListSubreddits(context, msg)
+	case *pb.GetTrendingSubredditsMessage:
+		m.handleGetTrendingSubreddits(context, msg)
+	}
+}
+
+func (m *memberActor) handleCheckSession(context actor.Context, msg *pb.CheckSessionMessage) {
+	claims, err := m.tokens.Verify(msg.Token)
+	if err == nil {
+		err = revokedIfUnknown(m.checkStoredSession(claims))
+	}
+	if err != nil {
+		context.Respond(errorResponse(err))
+		return
+	}
+	context.Respond(&pb.SuccessResponse{Message: "Session is valid"})
+}
+
+func (m *memberActor) handleExportUserContent(context actor.Context, msg *pb.ExportUserContentMessage) {
+	archive := &userArchive{}
+	err := m.storedContent(msg.UserId, archive)
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(archive)
+	}
+	if err != nil {
+		m.fail(context, err)
+		return
+	}
+	context.Respond(&pb.UserDataExport{UserId: msg.UserId, Archive: data})
+}
+
+// handleCreditToDeleted is the part of deleting an account that concerns the
+// content stored on this member.
+func (m *memberActor) handleCreditToDeleted(context actor.Context, msg *pb.CreditToDeletedMessage) {
+	if err := m.store.CreditToDeleted(msg.UserId); err != nil {
+		m.fail(context, err)
+		return
+	}
+	m.publish(context, &events.AccountDeleted{UserID: msg.UserId})
+	context.Respond(&pb.SuccessResponse{Message: "Content credited successfully"})
+}
+
+// memberSet holds the addresses of the cluster's members, as of its latest
+// topology event.
+type memberSet struct {
+	mu        sync.RWMutex
+	addresses []string
+}
+
+func (s *memberSet) update(topology *cluster.ClusterTopology) {
+	addresses := make([]string, 0, len(topology.Members))
+	for _, member := range topology.Members {
+		addresses = append(addresses, member.Address())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addresses = addresses
+}
+
+func (s *memberSet) list() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.addresses
+}
@@ -0,0 +1,223 @@
+package actor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/store"
+)
+
+// fromDirectMessage is the inverse of directMessage.
+func fromDirectMessage(msg *pb.DirectMessageMessage) *models.DirectMessage {
+	return &models.DirectMessage{
+		ID:        msg.GetId(),
+		FromID:    msg.GetFromId(),
+		ToID:      msg.GetToId(),
+		Content:   msg.GetContent(),
+		Timestamp: msg.GetTimestamp(),
+		ReplyToID: msg.GetReplyToId(),
+		Read:      msg.GetRead(),
+	}
+}
+
+// copyMessage sends the sender of message a copy, as the recipient's actor
+// stored it. In cluster mode the sender may be stored on another member,
+// which would otherwise not list the message among the ones they sent.
+func (e *engineCore) copyMessage(context actor.Context, message *models.DirectMessage) {
+	if e.cluster == nil || message.FromID == message.ToID {
+		return
+	}
+	context.Send(e.owner(context, UserKind, message.FromID), &pb.MessageCopyMessage{Message: directMessage(message)})
+}
+
+// copyRead is copyMessage for the messages userID has just marked read.
+func (e *engineCore) copyRead(context actor.Context, userID string, messageIDs []string) {
+	if e.cluster == nil || len(messageIDs) == 0 {
+		return
+	}
+	received, err := e.store.GetMessages(userID)
+	if err != nil {
+		return
+	}
+	read := make(map[string]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		read[id] = true
+	}
+	for _, message := range received {
+		if read[message.ID] {
+			e.copyMessage(context, message)
+		}
+	}
+}
+
+func directMessage(message *models.DirectMessage) *pb.DirectMessageMessage {
+	return &pb.DirectMessageMessage{
+		Id:        message.ID,
+		FromId:    message.FromID,
+		ToId:      message.ToID,
+		Content:   message.Content,
+		Timestamp: message.Timestamp,
+		ReplyToId: message.ReplyToID,
+		Read:      message.Read,
+	}
+}
+
+// checkReply fails unless replyToID is a message between fromID and toID.
+func (e *engineCore) checkReply(fromID, toID, replyToID string) error {
+	conversation, err := e.store.GetConversation(fromID, toID)
+	if err != nil {
+		return err
+	}
+	for _, message := range conversation {
+		if message.ID == replyToID {
+			return nil
+		}
+	}
+	return &requestError{kind: store.ErrNotFound, message: "message " + replyToID + " not found in conversation"}
+}
+
+// conversation is what GetConversationsMessage shows of one counterpart.
+type conversation struct {
+	otherID string
+	last    *models.DirectMessage
+	unread  int32
+}
+
+// conversationOrder lists conversations by their latest message, newest
+// first.
+var conversationOrder = pagination.Order{TimeDescending: true}
+
+func conversationKey(c *conversation) pagination.Key {
+	return pagination.Key{Time: c.last.Timestamp, ID: c.otherID}
+}
+
+// conversations groups the messages userID received and sent by counterpart,
+// in conversationOrder.
+func (e *engineCore) conversations(userID string) ([]*conversation, error) {
+	received, err := e.store.GetMessages(userID)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := e.store.GetSentMessages(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byOther := make(map[string]*conversation)
+	add := func(otherID string, message *models.DirectMessage) *conversation {
+		c, exists := byOther[otherID]
+		if !exists {
+			c = &conversation{otherID: otherID}
+			byOther[otherID] = c
+		}
+		if c.last == nil || (pagination.Order{}).Compare(messageKey(c.last), messageKey(message)) < 0 {
+			c.last = message
+		}
+		return c
+	}
+	for _, message := range received {
+		c := add(message.FromID, message)
+		if !message.Read {
+			c.unread++
+		}
+	}
+	for _, message := range sent {
+		// Messages to oneself were counted with the inbox.
+		if message.ToID != userID {
+			add(message.ToID, message)
+		}
+	}
+
+	list := make([]*conversation, 0, len(byOther))
+	for _, c := range byOther {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return conversationOrder.Compare(conversationKey(list[i]), conversationKey(list[j])) < 0
+	})
+	return list, nil
+}
+
+func (e *engineCore) handleGetConversations(context actor.Context, msg *pb.GetConversationsMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	list, err := e.conversations(msg.UserId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	result := pagination.Paginate(list, conversationKey, conversationOrder, page, time.Time{})
+
+	response := &pb.ConversationsResponse{
+		Conversations: make([]*pb.Conversation, 0, len(result.Items)),
+		NextCursor:    result.Next,
+		PrevCursor:    result.Prev,
+	}
+	for _, c := range list {
+		response.Unread += c.unread
+	}
+	for _, c := range result.Items {
+		response.Conversations = append(response.Conversations, &pb.Conversation{
+			OtherId:     c.otherID,
+			LastMessage: directMessage(c.last),
+			Unread:      c.unread,
+		})
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
+
+// handleMarkRead marks the listed messages read, and with WithId every unread
+// message from that user as well.
+func (e *engineCore) handleMarkRead(context actor.Context, msg *pb.MarkReadMessage) {
+	start := time.Now()
+	if !e.authorize(context, msg.GetToken(), msg.GetUserId()) {
+		return
+	}
+	if len(msg.MessageIds) == 0 && msg.WithId == "" {
+		e.fail(context, invalidf("message_ids or with_id is required"))
+		return
+	}
+
+	ids := append([]string(nil), msg.MessageIds...)
+	if msg.WithId != "" {
+		conversation, err := e.store.GetConversation(msg.UserId, msg.WithId)
+		if err != nil {
+			e.fail(context, err)
+			return
+		}
+		for _, message := range conversation {
+			if message.ToID == msg.UserId && !message.Read {
+				ids = append(ids, message.ID)
+			}
+		}
+	}
+
+	if err := e.store.MarkRead(msg.UserId, ids); err != nil {
+		e.fail(context, err)
+		return
+	}
+	e.copyRead(context, msg.UserId, ids)
+
+	if len(ids) > 0 {
+		e.publish(context, &events.MessagesRead{UserID: msg.UserId, MessageIDs: ids})
+	}
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Messages marked read"})
+}
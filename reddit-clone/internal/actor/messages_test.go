@@ -0,0 +1,84 @@
+package actor
+
+import (
+	"strings"
+	"testing"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store/memory"
+)
+
+func TestConversations(t *testing.T) {
+	store := memory.NewMemoryStore()
+	for i, message := range []*models.DirectMessage{
+		{ID: "m1", FromID: "alice", ToID: "bob", Content: "hi bob"},
+		{ID: "m2", FromID: "bob", ToID: "alice", Content: "hi alice", ReplyToID: "m1"},
+		{ID: "m3", FromID: "alice", ToID: "bob", Content: "how are you?"},
+		{ID: "m4", FromID: "carol", ToID: "bob", Content: "hello"},
+	} {
+		message.Timestamp = int64(100 * (i + 1))
+		store.SendMessage(message)
+	}
+	// carol only needs to exist to receive bob's reply.
+	store.CreateUser(&models.User{ID: "carol", Username: "carol"})
+
+	engine := newTestEngine(t, store)
+
+	alice := engine.registerAndLogin("alice")
+	bob := engine.registerAndLogin("bob")
+
+	// A reply must answer a message between the same two users.
+	result := engine.request(&pb.DirectMessageMessage{Id: "m5", FromId: "bob", ToId: "carol", ReplyToId: "m1", Token: bob})
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse replying to another conversation, got %v", result)
+	}
+	result = engine.request(&pb.DirectMessageMessage{Id: "m5", FromId: "bob", ToId: "carol", ReplyToId: "m4", Token: bob})
+	if _, ok := result.(*pb.SuccessResponse); !ok {
+		t.Fatalf("Expected SuccessResponse replying to carol, got %v", result)
+	}
+
+	thread := engine.request(&pb.GetDirectMessagesMessage{UserId: "alice", WithId: "bob", Token: alice}).(*pb.DirectMessagesResponse)
+	var ids []string
+	for _, message := range thread.Messages {
+		ids = append(ids, message.Id)
+	}
+	if got := strings.Join(ids, ","); got != "m1,m2,m3" {
+		t.Errorf("Expected both sides of the thread in order, got %s", got)
+	}
+
+	if resp, ok := engine.request(&pb.GetConversationsMessage{UserId: "bob", Token: alice}).(*pb.ErrorResponse); !ok || resp.Code != pb.ErrorCode_FORBIDDEN {
+		t.Errorf("Expected FORBIDDEN listing another user's conversations, got %v", resp)
+	}
+	list := engine.request(&pb.GetConversationsMessage{UserId: "bob", Token: bob}).(*pb.ConversationsResponse)
+	if list.Unread != 3 || len(list.Conversations) != 2 {
+		t.Fatalf("Expected 2 conversations with 3 unread messages, got %v", list)
+	}
+	if c := list.Conversations[0]; c.OtherId != "carol" || c.LastMessage.Id != "m5" || c.Unread != 1 {
+		t.Errorf("Expected carol's conversation first, got %v", c)
+	}
+	if c := list.Conversations[1]; c.OtherId != "alice" || c.LastMessage.Id != "m3" || c.Unread != 2 {
+		t.Errorf("Expected alice's conversation second, got %v", c)
+	}
+
+	// Only the recipient can mark a message read.
+	result = engine.request(&pb.MarkReadMessage{UserId: "alice", MessageIds: []string{"m1"}, Token: alice})
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse marking a sent message read, got %v", result)
+	}
+	if _, ok := engine.request(&pb.MarkReadMessage{UserId: "bob", WithId: "alice", Token: bob}).(*pb.SuccessResponse); !ok {
+		t.Fatal("Expected SuccessResponse marking alice's messages read")
+	}
+
+	list = engine.request(&pb.GetConversationsMessage{UserId: "bob", Limit: 1, Token: bob}).(*pb.ConversationsResponse)
+	if list.Unread != 1 || len(list.Conversations) != 1 || list.NextCursor == "" {
+		t.Fatalf("Expected one page of one conversation and 1 unread, got %v", list)
+	}
+	list = engine.request(&pb.GetConversationsMessage{UserId: "bob", After: list.NextCursor, Token: bob}).(*pb.ConversationsResponse)
+	if len(list.Conversations) != 1 || list.Conversations[0].Unread != 0 || !list.Conversations[0].LastMessage.Read {
+		t.Errorf("Expected alice's conversation to be read, got %v", list.Conversations)
+	}
+	if got := strings.Count(strings.Join(engine.events.Types(), ","), "messages_read"); got != 1 {
+		t.Errorf("Expected one messages_read event, got %v", engine.events.Types())
+	}
+}
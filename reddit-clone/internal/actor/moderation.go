@@ -0,0 +1,219 @@
+package actor
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/store"
+	"reddit-clone/pkg/utils"
+)
+
+// Moderation requests are routed to the subreddit they concern, so they are
+// serialised with the posts, comments and votes they affect: a ban applies
+// to every request the subreddit's actor handles after it.
+
+// moderate checks that token is a live session of moderatorID and that
+// moderatorID moderates subredditID. Otherwise it answers the request with an
+// error and returns false.
+func (e *engineCore) moderate(context actor.Context, token, moderatorID, subredditID string) bool {
+	if !e.authorize(context, token, moderatorID) {
+		return false
+	}
+	subreddit, err := e.store.GetSubreddit(subredditID)
+	if err == nil && !isModerator(subreddit, moderatorID) {
+		err = forbiddenf("user %s is not a moderator of subreddit %s", moderatorID, subredditID)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return false
+	}
+	return true
+}
+
+// isModerator also accepts the creator of a subreddit created before
+// moderators existed.
+func isModerator(subreddit *models.Subreddit, userID string) bool {
+	return subreddit.Moderators[userID] || subreddit.CreatorID == userID
+}
+
+// checkNotBanned answers the request with an error and returns false if
+// userID is banned from subredditID. Unknown subreddits ban nobody.
+func (e *engineCore) checkNotBanned(context actor.Context, subredditID, userID string) bool {
+	subreddit, err := e.store.GetSubreddit(subredditID)
+	if err != nil || !subreddit.Banned[userID] {
+		return true
+	}
+	e.fail(context, forbiddenf("user %s is banned from subreddit %s", userID, subredditID))
+	return false
+}
+
+// logModAction appends an applied action to the moderation log, publishes it
+// and answers the request.
+func (e *engineCore) logModAction(context actor.Context, start time.Time, action *models.ModAction) {
+	action.ID = utils.GenerateID()
+	action.Created = time.Now().Unix()
+	if err := e.store.AddModAction(action); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.publish(context, &events.ModActionTaken{Action: action})
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Moderation action recorded successfully"})
+}
+
+func (e *engineCore) handleAddModerator(context actor.Context, msg *pb.AddModeratorMessage) {
+	start := time.Now()
+	if !e.moderate(context, msg.Token, msg.ModeratorId, msg.SubredditId) {
+		return
+	}
+
+	if err := e.store.AddModerator(msg.SubredditId, msg.UserId); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.logModAction(context, start, &models.ModAction{
+		SubredditID: msg.SubredditId,
+		ModeratorID: msg.ModeratorId,
+		Action:      models.ModAddModerator,
+		TargetID:    msg.UserId,
+	})
+}
+
+func (e *engineCore) handleBanUser(context actor.Context, msg *pb.BanUserMessage) {
+	start := time.Now()
+	if !e.moderate(context, msg.Token, msg.ModeratorId, msg.SubredditId) {
+		return
+	}
+
+	action := models.ModBan
+	if msg.Unban {
+		action = models.ModUnban
+	}
+	if err := e.store.SetBanned(msg.SubredditId, msg.UserId, !msg.Unban); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.logModAction(context, start, &models.ModAction{
+		SubredditID: msg.SubredditId,
+		ModeratorID: msg.ModeratorId,
+		Action:      action,
+		TargetID:    msg.UserId,
+		Reason:      msg.Reason,
+	})
+}
+
+// handleRemoveContent removes a post or a comment. Removed content stays in
+// the store: posts drop out of feeds and comments lose their text and author.
+func (e *engineCore) handleRemoveContent(context actor.Context, msg *pb.RemoveContentMessage) {
+	start := time.Now()
+
+	action, subredditID := models.ModRemovePost, e.postSubreddit(msg.TargetId)
+	if subredditID == "" {
+		action, subredditID = models.ModRemoveComment, e.targetSubreddit(msg.TargetId)
+	}
+	if subredditID == "" {
+		e.fail(context, &store.NotFoundError{Kind: "post or comment", ID: msg.TargetId})
+		return
+	}
+	if !e.moderate(context, msg.Token, msg.ModeratorId, subredditID) {
+		return
+	}
+
+	remove := e.store.RemovePost
+	if action == models.ModRemoveComment {
+		remove = e.store.RemoveComment
+	}
+	if err := remove(msg.TargetId); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.logModAction(context, start, &models.ModAction{
+		SubredditID: subredditID,
+		ModeratorID: msg.ModeratorId,
+		Action:      action,
+		TargetID:    msg.TargetId,
+		Reason:      msg.Reason,
+	})
+}
+
+func (e *engineCore) handleLockPost(context actor.Context, msg *pb.LockPostMessage) {
+	start := time.Now()
+
+	subredditID := e.postSubreddit(msg.PostId)
+	if subredditID == "" {
+		e.fail(context, &store.NotFoundError{Kind: "post", ID: msg.PostId})
+		return
+	}
+	if !e.moderate(context, msg.Token, msg.ModeratorId, subredditID) {
+		return
+	}
+
+	action := models.ModLock
+	if msg.Unlock {
+		action = models.ModUnlock
+	}
+	if err := e.store.SetPostLocked(msg.PostId, !msg.Unlock); err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.logModAction(context, start, &models.ModAction{
+		SubredditID: subredditID,
+		ModeratorID: msg.ModeratorId,
+		Action:      action,
+		TargetID:    msg.PostId,
+	})
+}
+
+// modActionKey orders a moderation log oldest first, as store.Store returns it.
+func modActionKey(action *models.ModAction) pagination.Key {
+	return pagination.Key{Time: action.Created, ID: action.ID}
+}
+
+// handleGetModLog lists a subreddit's moderation log. Like the subreddit
+// itself, the log is public.
+func (e *engineCore) handleGetModLog(context actor.Context, msg *pb.GetModLogMessage) {
+	start := time.Now()
+
+	page, err := pagination.NewRequest(msg.Limit, msg.After, msg.Before)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	actions, err := e.store.GetModLog(msg.SubredditId)
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+	log := pagination.Paginate(actions, modActionKey, pagination.Order{}, page, time.Time{})
+
+	response := &pb.ModLogResponse{
+		Actions:    make([]*pb.ModAction, 0, len(log.Items)),
+		NextCursor: log.Next,
+		PrevCursor: log.Prev,
+	}
+	for _, action := range log.Items {
+		response.Actions = append(response.Actions, &pb.ModAction{
+			Id:          action.ID,
+			SubredditId: action.SubredditID,
+			ModeratorId: action.ModeratorID,
+			Action:      action.Action,
+			TargetId:    action.TargetID,
+			Reason:      action.Reason,
+			CreatedAt:   action.Created,
+		})
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(response)
+}
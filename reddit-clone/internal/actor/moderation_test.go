@@ -0,0 +1,67 @@
+package actor
+
+import (
+	"testing"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/store/memory"
+)
+
+func TestModeration(t *testing.T) {
+	engine := newTestEngine(t, memory.NewMemoryStore())
+
+	alice := engine.registerAndLogin("alice")
+	bob := engine.registerAndLogin("bob")
+	carol := engine.registerAndLogin("carol")
+	engine.succeed(&pb.SubredditMessage{Id: "s1", Name: "s1", CreatorId: "alice", Token: alice})
+	engine.succeed(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "carol", Token: carol})
+	engine.succeed(&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "carol", Title: "spam spam", Token: carol})
+	engine.succeed(&pb.PostMessage{Id: "p2", SubredditId: "s1", AuthorId: "carol", Title: "fine", Token: carol})
+	engine.succeed(&pb.CommentMessage{Id: "c1", PostId: "p2", AuthorId: "carol", Content: "rude", Token: carol})
+
+	// Only moderators moderate; the creator is the first one.
+	engine.failContaining(&pb.BanUserMessage{SubredditId: "s1", UserId: "carol", ModeratorId: "bob", Token: bob}, "not a moderator")
+	engine.succeed(&pb.AddModeratorMessage{SubredditId: "s1", UserId: "bob", ModeratorId: "alice", Token: alice})
+	engine.succeed(&pb.BanUserMessage{SubredditId: "s1", UserId: "carol", ModeratorId: "bob", Reason: "spam", Token: bob})
+
+	engine.failContaining(&pb.PostMessage{Id: "p3", SubredditId: "s1", AuthorId: "carol", Token: carol}, "banned")
+	engine.failContaining(&pb.CommentMessage{Id: "c2", PostId: "p2", AuthorId: "carol", Token: carol}, "banned")
+	engine.failContaining(&pb.VoteMessage{TargetId: "p2", UserId: "carol", IsUpvote: true, Token: carol}, "banned")
+
+	engine.succeed(&pb.RemoveContentMessage{TargetId: "p1", ModeratorId: "alice", Reason: "spam", Token: alice})
+	engine.succeed(&pb.RemoveContentMessage{TargetId: "c1", ModeratorId: "alice", Token: alice})
+	engine.succeed(&pb.LockPostMessage{PostId: "p2", ModeratorId: "bob", Token: bob})
+	engine.failContaining(&pb.CommentMessage{Id: "c3", PostId: "p2", AuthorId: "alice", Token: alice}, "locked")
+
+	feed := engine.request(&pb.GetFeedMessage{SubredditIds: []string{"s1"}}).(*pb.FeedResponse)
+	if len(feed.Posts) != 1 || feed.Posts[0].Id != "p2" || !feed.Posts[0].Locked {
+		t.Errorf("Expected only the locked p2 in the feed, got %v", feed.Posts)
+	}
+	comments := engine.request(&pb.GetCommentsMessage{PostId: "p2"}).(*pb.CommentsResponse)
+	if len(comments.Comments) != 1 || !comments.Comments[0].Removed || comments.Comments[0].Content != "" || comments.Comments[0].AuthorId != "" {
+		t.Errorf("Expected c1 to be blanked, got %v", comments.Comments)
+	}
+	results := engine.request(&pb.SearchMessage{Query: "spam"}).(*pb.SearchResponse)
+	if len(results.Results) != 0 {
+		t.Errorf("Expected removed content to drop out of search, got %v", results.Results)
+	}
+
+	log := engine.request(&pb.GetModLogMessage{SubredditId: "s1"}).(*pb.ModLogResponse)
+	// Actions within the same second are ordered by ID, so only compare
+	// which actions were logged.
+	actions := map[string]*pb.ModAction{}
+	for _, action := range log.Actions {
+		actions[action.Action+":"+action.TargetId] = action
+	}
+	for _, want := range []string{"add_moderator:bob", "ban:carol", "remove_post:p1", "remove_comment:c1", "lock:p2"} {
+		if actions[want] == nil {
+			t.Errorf("Expected %s in the mod log, got %v", want, log.Actions)
+		}
+	}
+	if ban := actions["ban:carol"]; len(log.Actions) != 5 || ban == nil || ban.ModeratorId != "bob" || ban.Reason != "spam" {
+		t.Errorf("Expected 5 actions including bob's ban for spam, got %v", log.Actions)
+	}
+
+	engine.succeed(&pb.BanUserMessage{SubredditId: "s1", UserId: "carol", ModeratorId: "alice", Unban: true, Token: alice})
+	engine.succeed(&pb.PostMessage{Id: "p3", SubredditId: "s1", AuthorId: "carol", Token: carol})
+}
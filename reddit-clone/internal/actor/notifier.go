@@ -0,0 +1,125 @@
+package actor
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/ranking"
+	"reddit-clone/internal/store"
+)
+
+// Kinds of Notification.
+const (
+	NotifyMessage = "message" // a direct message to the user
+	NotifyReply   = "reply"   // a comment on the user's post or a reply to their comment
+	NotifyPost    = "post"    // a new post in a subreddit the user joined
+	NotifyKarma   = "karma"   // a vote on the user's post or comment, sent by their UserActor
+)
+
+// notifierPrefix names the engine's notifier.
+const notifierPrefix = "notifier"
+
+// notifier turns the domain events the engine publishes into Notifications
+// for the users they concern. It sends them to its parent, the router, which
+// delivers them to each user's UserActor like any other request.
+type notifier struct {
+	store        store.Store
+	now          ranking.Clock
+	subscription *eventstream.Subscription
+}
+
+func (n *notifier) Receive(context actor.Context) {
+	switch event := context.Message().(type) {
+	case *actor.Started:
+		// Events are published from whichever actor handled the write;
+		// handle them here instead of on that actor's goroutine.
+		root, self := context.ActorSystem().Root, context.Self()
+		// See events.Attach for why this does not use a predicate.
+		n.subscription = context.ActorSystem().EventStream.Subscribe(func(event interface{}) {
+			if notifies(event) {
+				root.Send(self, event)
+			}
+		})
+	case *actor.Stopping, *actor.Restarting:
+		context.ActorSystem().EventStream.Unsubscribe(n.subscription)
+	case *events.MessageSent:
+		message := event.Message
+		n.notify(context, message.FromID, &pb.Notification{
+			UserId:   message.ToID,
+			Kind:     NotifyMessage,
+			TargetId: message.ID,
+			Content:  message.Content,
+		})
+	case *events.CommentAdded:
+		n.commentAdded(context, event)
+	case *events.PostCreated:
+		n.postCreated(context, event)
+	}
+}
+
+func notifies(event interface{}) bool {
+	switch event.(type) {
+	case *events.MessageSent, *events.CommentAdded, *events.PostCreated:
+		return true
+	}
+	return false
+}
+
+// notify sends notification to its recipient unless they caused it themselves.
+func (n *notifier) notify(context actor.Context, actorID string, notification *pb.Notification) {
+	if notification.UserId == "" || notification.UserId == actorID {
+		return
+	}
+	notification.ActorId = actorID
+	notification.CreatedAt = n.now().Unix()
+	context.Send(context.Parent(), notification)
+}
+
+// commentAdded notifies the author of the comment replied to, or of the post
+// for top-level comments.
+func (n *notifier) commentAdded(context actor.Context, event *events.CommentAdded) {
+	comment := event.Comment
+	post, err := n.store.GetPost(comment.PostID)
+	if err != nil {
+		return
+	}
+	recipient := post.AuthorID
+	if comment.ParentID != "" {
+		parent, err := n.store.GetComment(comment.ParentID)
+		if err != nil {
+			return
+		}
+		recipient = parent.AuthorID
+	}
+	n.notify(context, comment.AuthorID, &pb.Notification{
+		UserId:      recipient,
+		Kind:        NotifyReply,
+		TargetId:    comment.ID,
+		PostId:      post.ID,
+		SubredditId: post.SubredditID,
+		Content:     comment.Content,
+	})
+}
+
+// postCreated notifies every member of the post's subreddit. The router
+// drops the notifications of members without a running UserActor, and in
+// cluster mode the grains it activates stop again when idle.
+func (n *notifier) postCreated(context actor.Context, event *events.PostCreated) {
+	post := event.Post
+	subreddit, err := n.store.GetSubreddit(post.SubredditID)
+	if err != nil {
+		return
+	}
+	for member := range subreddit.Members {
+		n.notify(context, post.AuthorID, &pb.Notification{
+			UserId:      member,
+			Kind:        NotifyPost,
+			TargetId:    post.ID,
+			PostId:      post.ID,
+			SubredditId: post.SubredditID,
+			Content:     post.Title,
+		})
+	}
+}
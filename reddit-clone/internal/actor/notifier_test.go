@@ -0,0 +1,181 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+// subscribe spawns an actor that subscribes to userID's notifications and
+// passes them on.
+func subscribe(t *testing.T, root *actor.RootContext, enginePID *actor.PID, userID, token string) <-chan *pb.Notification {
+	t.Helper()
+	notifications := make(chan *pb.Notification, 16)
+	subscribed := make(chan interface{}, 1)
+	root.Spawn(actor.PropsFromFunc(func(context actor.Context) {
+		switch msg := context.Message().(type) {
+		case *actor.Started:
+			context.Request(enginePID, &pb.SubscribeMessage{UserId: userID, Token: token})
+		case *pb.SuccessResponse, *pb.ErrorResponse:
+			subscribed <- msg
+		case *pb.Notification:
+			notifications <- msg
+		}
+	}))
+
+	select {
+	case result := <-subscribed:
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse subscribing %s, got %v", userID, result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Subscribing %s timed out", userID)
+	}
+	return notifications
+}
+
+func nextNotification(t *testing.T, notifications <-chan *pb.Notification) *pb.Notification {
+	t.Helper()
+	select {
+	case n := <-notifications:
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a notification")
+		return nil
+	}
+}
+
+func TestSubscribersReceiveNotifications(t *testing.T) {
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	aliceToken := registerAndLogin(t, system.Root, enginePID, "alice")
+	bobToken := registerAndLogin(t, system.Root, enginePID, "bob")
+	notifications := subscribe(t, system.Root, enginePID, "bob", bobToken)
+
+	send := func(msg interface{}) {
+		t.Helper()
+		result, err := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response from engine actor: %v", err)
+		}
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+
+	send(&pb.SubredditMessage{Id: "s1", Name: "s1", CreatorId: "bob", Token: bobToken})
+	send(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "bob", Token: bobToken})
+	send(&pb.JoinSubredditMessage{SubredditId: "s1", UserId: "alice", Token: aliceToken})
+	// Bob's own actions do not notify him.
+	send(&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "bob", Title: "mine", Token: bobToken})
+
+	tests := []struct {
+		msg   interface{}
+		check func(n *pb.Notification) bool
+	}{
+		{
+			&pb.DirectMessageMessage{Id: "m1", FromId: "alice", ToId: "bob", Content: "hi", Token: aliceToken},
+			func(n *pb.Notification) bool {
+				return n.Kind == NotifyMessage && n.TargetId == "m1" && n.Content == "hi"
+			},
+		},
+		{
+			&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Content: "nice", Token: aliceToken},
+			func(n *pb.Notification) bool { return n.Kind == NotifyReply && n.TargetId == "c1" && n.PostId == "p1" },
+		},
+		{
+			&pb.VoteMessage{TargetId: "p1", UserId: "alice", IsUpvote: true, Token: aliceToken},
+			func(n *pb.Notification) bool { return n.Kind == NotifyKarma && n.TargetId == "p1" && n.Karma == 1 },
+		},
+		{
+			&pb.PostMessage{Id: "p2", SubredditId: "s1", AuthorId: "alice", Title: "news", Token: aliceToken},
+			func(n *pb.Notification) bool {
+				return n.Kind == NotifyPost && n.PostId == "p2" && n.SubredditId == "s1"
+			},
+		},
+	}
+	for _, tt := range tests {
+		send(tt.msg)
+		n := nextNotification(t, notifications)
+		if n.UserId != "bob" || n.ActorId != "alice" || !tt.check(n) {
+			t.Errorf("Unexpected notification for %T: %v", tt.msg, n)
+		}
+	}
+
+	select {
+	case n := <-notifications:
+		t.Errorf("Expected no further notifications, got %v", n)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscribeRequiresSessionOfUser(t *testing.T) {
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	aliceToken := registerAndLogin(t, system.Root, enginePID, "alice")
+	registerAndLogin(t, system.Root, enginePID, "bob")
+
+	result, err := system.Root.RequestFuture(enginePID, &pb.SubscribeMessage{UserId: "bob", Token: aliceToken}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to get response from engine actor: %v", err)
+	}
+	if _, ok := result.(*pb.ErrorResponse); !ok {
+		t.Errorf("Expected ErrorResponse subscribing with another user's token, got %v", result)
+	}
+}
+
+func TestNotificationsDoNotStartIdleUserActors(t *testing.T) {
+	system := actor.NewActorSystem()
+	engine := NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	engine.userIdle = 50 * time.Millisecond
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+	running := func(userID string) bool {
+		_, ok := system.ProcessRegistry.GetLocal(enginePID.Id + "/" + childName(UserKind, userID))
+		return ok
+	}
+
+	aliceToken := registerAndLogin(t, system.Root, enginePID, "alice")
+	bobToken := registerAndLogin(t, system.Root, enginePID, "bob")
+	carolToken := registerAndLogin(t, system.Root, enginePID, "carol")
+	createSubreddit(t, system.Root, enginePID, aliceToken, "alice", "s1")
+	for userID, token := range map[string]string{"bob": bobToken, "carol": carolToken} {
+		result, err := system.Root.RequestFuture(enginePID, &pb.JoinSubredditMessage{SubredditId: "s1", UserId: userID, Token: token}, 5*time.Second).Result()
+		if _, ok := result.(*pb.SuccessResponse); err != nil || !ok {
+			t.Fatalf("Expected %s to join s1, got %v (%v)", userID, result, err)
+		}
+	}
+	notifications := subscribe(t, system.Root, enginePID, "bob", bobToken)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for running("carol") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if running("carol") {
+		t.Fatal("Expected carol's idle UserActor to stop")
+	}
+	if !running("bob") {
+		t.Fatal("Expected bob's UserActor to keep running while he is subscribed")
+	}
+
+	result, err := system.Root.RequestFuture(enginePID, &pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Title: "news", Token: aliceToken}, 5*time.Second).Result()
+	if _, ok := result.(*pb.SuccessResponse); err != nil || !ok {
+		t.Fatalf("Expected the post to succeed, got %v (%v)", result, err)
+	}
+	if n := nextNotification(t, notifications); n.Kind != NotifyPost || n.PostId != "p1" {
+		t.Errorf("Expected bob to be notified of p1, got %v", n)
+	}
+	// Give the notification for carol time to reach the engine.
+	time.Sleep(20 * time.Millisecond)
+	if running("carol") {
+		t.Error("Expected the notification for carol not to start her UserActor")
+	}
+}
@@ -0,0 +1,85 @@
+package actor
+
+import (
+	"errors"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// The checks below validate what a write refers to before the store accepts
+// it, so the store never holds a post in a missing subreddit or a comment on
+// a missing post. Authors are not looked up: a session token is only issued
+// to a registered user, and in cluster mode the user is stored on the member
+// hosting their grain rather than the one handling the write. Each check
+// returns a *store.NotFoundError or *store.ReferenceError.
+
+// checkPost requires the subreddit of post to exist and its author to be a
+// member or moderator.
+func (e *engineCore) checkPost(post *models.Post) error {
+	subreddit, err := e.store.GetSubreddit(post.SubredditID)
+	if err != nil {
+		return err
+	}
+	if !subreddit.Members[post.AuthorID] && !isModerator(subreddit, post.AuthorID) {
+		return &store.ReferenceError{
+			Field:  "subreddit_id",
+			ID:     post.SubredditID,
+			Reason: "user " + post.AuthorID + " is not a member",
+			Err:    store.ErrForbidden,
+		}
+	}
+	return nil
+}
+
+// checkComment requires the post of comment to exist and its parent, if any,
+// to be a comment on the same post. It returns the post.
+func (e *engineCore) checkComment(comment *models.Comment) (*models.Post, error) {
+	post, err := e.store.GetPost(comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.ParentID == "" {
+		return post, nil
+	}
+	parent, err := e.store.GetComment(comment.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.PostID != comment.PostID {
+		return nil, &store.ReferenceError{
+			Field:  "parent_id",
+			ID:     comment.ParentID,
+			Reason: "comment is on post " + parent.PostID + ", not " + comment.PostID,
+			Err:    store.ErrInvalid,
+		}
+	}
+	return post, nil
+}
+
+// checkVoteTarget requires targetID to be a post or a comment.
+func (e *engineCore) checkVoteTarget(targetID string) error {
+	if _, err := e.store.GetPost(targetID); err == nil {
+		return nil
+	}
+	_, err := e.store.GetComment(targetID)
+	var notFound *store.NotFoundError
+	if errors.As(err, &notFound) {
+		return &store.NotFoundError{Kind: "post or comment", ID: targetID}
+	}
+	return err
+}
+
+// checkRecipient requires the recipient of a direct message to exist and not
+// be deleted. Messages are handled by the recipient's actor, which sees the
+// recipient's account in cluster mode too.
+func (e *engineCore) checkRecipient(userID string) error {
+	user, err := e.store.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.Deleted {
+		return &store.NotFoundError{Kind: "user", ID: userID}
+	}
+	return nil
+}
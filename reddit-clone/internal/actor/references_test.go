@@ -0,0 +1,84 @@
+package actor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+func TestWritesValidateReferences(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := memory.NewMemoryStore()
+	engine := NewEngineActor(store, metrics.NewRedditMetrics())
+	enginePID := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engine }))
+
+	alice := registerAndLogin(t, system.Root, enginePID, "alice")
+	bob := registerAndLogin(t, system.Root, enginePID, "bob")
+	createSubreddit(t, system.Root, enginePID, alice, "alice", "s1")
+	for _, msg := range []interface{}{
+		&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: alice},
+		&pb.PostMessage{Id: "p2", SubredditId: "s1", AuthorId: "alice", Token: alice},
+		&pb.CommentMessage{Id: "c1", PostId: "p1", AuthorId: "alice", Token: alice},
+	} {
+		result, _ := system.Root.RequestFuture(enginePID, msg, 5*time.Second).Result()
+		if _, ok := result.(*pb.SuccessResponse); !ok {
+			t.Fatalf("Expected SuccessResponse for %T, got %v", msg, result)
+		}
+	}
+
+	tests := []struct {
+		msg     interface{}
+		want    string
+		code    pb.ErrorCode
+		details map[string]string
+	}{
+		{&pb.PostMessage{Id: "p3", SubredditId: "missing", AuthorId: "alice", Token: alice}, "subreddit missing not found",
+			pb.ErrorCode_NOT_FOUND, map[string]string{"kind": "subreddit", "id": "missing"}},
+		{&pb.PostMessage{Id: "p3", SubredditId: "s1", AuthorId: "bob", Token: bob}, "subreddit_id s1: user bob is not a member",
+			pb.ErrorCode_FORBIDDEN, map[string]string{"field": "subreddit_id", "id": "s1"}},
+		{&pb.CommentMessage{Id: "c2", PostId: "missing", AuthorId: "bob", Token: bob}, "post missing not found",
+			pb.ErrorCode_NOT_FOUND, map[string]string{"kind": "post", "id": "missing"}},
+		{&pb.CommentMessage{Id: "c2", PostId: "p1", ParentId: "missing", AuthorId: "bob", Token: bob}, "comment missing not found",
+			pb.ErrorCode_NOT_FOUND, map[string]string{"kind": "comment", "id": "missing"}},
+		{&pb.CommentMessage{Id: "c2", PostId: "p2", ParentId: "c1", AuthorId: "bob", Token: bob}, "parent_id c1: comment is on post p1",
+			pb.ErrorCode_INVALID, map[string]string{"field": "parent_id", "id": "c1"}},
+		{&pb.VoteMessage{TargetId: "missing", UserId: "bob", IsUpvote: true, Token: bob}, "post or comment missing not found",
+			pb.ErrorCode_NOT_FOUND, map[string]string{"kind": "post or comment", "id": "missing"}},
+		{&pb.DirectMessageMessage{Id: "m1", FromId: "bob", ToId: "nobody", Token: bob}, "user nobody not found",
+			pb.ErrorCode_NOT_FOUND, map[string]string{"kind": "user", "id": "nobody"}},
+		{&pb.PostMessage{Id: "p1", SubredditId: "s1", AuthorId: "alice", Token: alice}, "post already exists",
+			pb.ErrorCode_ALREADY_EXISTS, nil},
+		{&pb.PostMessage{Id: "p3", SubredditId: "s1", AuthorId: "alice", Token: bob}, "session token does not belong to user alice",
+			pb.ErrorCode_FORBIDDEN, nil},
+		{&pb.PostMessage{Id: "p3", SubredditId: "s1", AuthorId: "alice", Token: "bogus"}, "invalid session token",
+			pb.ErrorCode_UNAUTHENTICATED, nil},
+	}
+	for _, tt := range tests {
+		result, err := system.Root.RequestFuture(enginePID, tt.msg, 5*time.Second).Result()
+		if err != nil {
+			t.Fatalf("Failed to get response for %T: %v", tt.msg, err)
+		}
+		resp, ok := result.(*pb.ErrorResponse)
+		if !ok || !strings.HasPrefix(resp.Error, tt.want) {
+			t.Errorf("Expected %q for %v, got %v", tt.want, tt.msg, result)
+			continue
+		}
+		if resp.Code != tt.code || !reflect.DeepEqual(resp.Details, tt.details) {
+			t.Errorf("Expected %s %v for %v, got %s %v", tt.code, tt.details, tt.msg, resp.Code, resp.Details)
+		}
+	}
+
+	if _, err := store.GetComment("c2"); err == nil {
+		t.Error("Expected no rejected comment to be stored")
+	}
+	if messages, _ := store.GetMessages("nobody"); len(messages) != 0 {
+		t.Errorf("Expected no message to an unknown user, got %v", messages)
+	}
+}
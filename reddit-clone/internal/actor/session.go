@@ -0,0 +1,117 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/auth"
+	"reddit-clone/internal/store"
+)
+
+// authorize checks that token is a live session of userID: signed, not
+// expired, and not revoked by a logout, a password change or deleting the
+// account. Otherwise it answers the request with an error and returns false.
+func (e *engineCore) authorize(context actor.Context, token, userID string) bool {
+	claims, err := e.tokens.Verify(token)
+	if err == nil && claims.UserID != userID {
+		err = forbiddenf("session token does not belong to user %s", userID)
+	}
+	if err == nil {
+		err = e.checkSession(claims, token)
+	}
+	if err != nil {
+		e.fail(context, err)
+		return false
+	}
+	return true
+}
+
+// checkSession compares verified claims with the revocations stored with
+// the user. In cluster mode a user missing from this member's store is
+// checked by the member hosting the user's grain.
+func (e *engineCore) checkSession(claims *auth.Claims, token string) error {
+	err := e.checkStoredSession(claims)
+	if errors.Is(err, store.ErrNotFound) && e.cluster != nil {
+		return e.checkRemoteSession(claims.UserID, token)
+	}
+	return revokedIfUnknown(err)
+}
+
+// checkStoredSession is checkSession against this member's store only.
+func (e *engineCore) checkStoredSession(claims *auth.Claims) error {
+	user, err := e.store.GetUser(claims.UserID)
+	if err != nil {
+		return err
+	}
+	if store.SessionRevoked(user, claims.ID, claims.IssuedAt) {
+		return auth.ErrTokenRevoked
+	}
+	return nil
+}
+
+// checkRemoteSession asks the member actor of the member that hosts the
+// grain of userID, which stores the user. It is not the grain itself: the
+// grain may be waiting on this actor, while the member actor never waits.
+func (e *engineCore) checkRemoteSession(userID, token string) error {
+	grain := e.cluster.Get(userID, UserKind)
+	if grain == nil {
+		return fmt.Errorf("no member hosts user %s", userID)
+	}
+	member := actor.NewPID(grain.Address, memberName)
+	result, err := e.cluster.ActorSystem.Root.RequestFuture(member, &pb.CheckSessionMessage{Token: token},
+		e.cluster.Config.RequestTimeoutTime).Result()
+	if err != nil {
+		return fmt.Errorf("checking session of user %s: %w", userID, err)
+	}
+	switch resp := result.(type) {
+	case *pb.SuccessResponse:
+		return nil
+	case *pb.ErrorResponse:
+		if resp.Code == pb.ErrorCode_UNAUTHENTICATED {
+			return &requestError{kind: auth.ErrTokenRevoked, message: resp.Error}
+		}
+		return errors.New(resp.Error)
+	default:
+		return fmt.Errorf("unexpected answer %T to a session check", result)
+	}
+}
+
+// revokedIfUnknown turns the store's answer for a user it does not know
+// into ErrTokenRevoked: the token outlived its user, e.g. when the store was
+// reset.
+func revokedIfUnknown(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return auth.ErrTokenRevoked
+	}
+	return err
+}
+
+// tokenUser returns the user a token was issued to, or "" for tokens that
+// do not verify.
+func (e *engineCore) tokenUser(token string) string {
+	claims, err := e.tokens.Verify(token)
+	if err != nil {
+		return ""
+	}
+	return claims.UserID
+}
+
+func (e *engineCore) handleLogoutMessage(context actor.Context, msg *pb.LogoutMessage) {
+	start := time.Now()
+
+	claims, err := e.tokens.Verify(msg.Token)
+	if err == nil {
+		err = e.store.EndSession(claims.UserID, claims.ID, claims.ExpiresAt, time.Now().Unix())
+	}
+	if err != nil {
+		e.fail(context, err)
+		return
+	}
+
+	e.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Logged out successfully"})
+}
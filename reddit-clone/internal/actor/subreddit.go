@@ -1 +1,23 @@
 package actor
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+)
+
+// SubredditActor handles the requests of one subreddit: creating it, joining
+// and leaving, posting, commenting, voting and reading its feed. Requests for
+// a subreddit are processed in order while other subreddits proceed in
+// parallel. It runs as a child of EngineActor or as a cluster grain.
+type SubredditActor struct {
+	*engineCore
+	subredditID string
+}
+
+func (s *SubredditActor) Receive(context actor.Context) {
+	if init, ok := context.Message().(*cluster.ClusterInit); ok {
+		s.subredditID = init.Identity.Identity
+		return
+	}
+	s.handle(context)
+}
@@ -0,0 +1,105 @@
+package actor
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/pkg/metrics"
+)
+
+// errInternal is what a client sees when its request made an actor panic.
+// The panic itself is only logged.
+const errInternal = "internal error"
+
+// SupervisionConfig controls how the engine recovers actors that panic.
+type SupervisionConfig struct {
+	// MaxRestarts is how often an actor may be restarted within Window
+	// before it is stopped instead. A stopped subreddit or user actor is
+	// spawned again by its next request. Zero stops on the first failure.
+	MaxRestarts int
+	Window      time.Duration
+	// Backoff delays a restart by Backoff for every failure within Window,
+	// so an actor that keeps failing is retried more and more slowly.
+	// Requests arriving in the meantime wait in its mailbox.
+	Backoff time.Duration
+}
+
+// DefaultSupervision matches protoactor's default policy: up to ten
+// immediate restarts within ten seconds.
+func DefaultSupervision() SupervisionConfig {
+	return SupervisionConfig{MaxRestarts: 10, Window: 10 * time.Second}
+}
+
+// Supervisor is the engine's supervisor strategy. It applies a
+// SupervisionConfig and logs and counts every decision it takes.
+type Supervisor struct {
+	config  SupervisionConfig
+	metrics *metrics.RedditMetrics
+}
+
+var _ actor.SupervisorStrategy = (*Supervisor)(nil)
+
+func NewSupervisor(config SupervisionConfig, metrics *metrics.RedditMetrics) *Supervisor {
+	return &Supervisor{config: config, metrics: metrics}
+}
+
+func (s *Supervisor) HandleFailure(_ *actor.ActorSystem, supervisor actor.Supervisor, child *actor.PID, rs *actor.RestartStatistics, reason interface{}, _ interface{}) {
+	kind := actorKind(child)
+	rs.Fail()
+	failures := rs.NumberOfFailures(s.config.Window)
+
+	// Workers answer a single request, so there is nothing to restart.
+	if kind == workerPrefix || failures > s.config.MaxRestarts {
+		rs.Reset()
+		s.record(kind, child, "stop", reason)
+		supervisor.StopChildren(child)
+		return
+	}
+
+	s.record(kind, child, "restart", reason)
+	delay := time.Duration(failures) * s.config.Backoff
+	if delay == 0 {
+		supervisor.RestartChildren(child)
+		return
+	}
+	time.AfterFunc(delay, func() {
+		supervisor.RestartChildren(child)
+	})
+}
+
+func (s *Supervisor) record(kind string, child *actor.PID, directive string, reason interface{}) {
+	log.Printf("Supervisor: %s %s after failure: %v", directive, child.Id, reason)
+	s.metrics.RecordActorFailure(kind, directive)
+}
+
+// workerPrefix names the engine's one-shot request workers.
+const workerPrefix = "worker"
+
+// actorKind returns the kind of an engine actor from its name, e.g.
+// "subreddit" for "engine/subreddit-golang".
+func actorKind(pid *actor.PID) string {
+	name := pid.Id[strings.LastIndex(pid.Id, "/")+1:]
+	if i := strings.IndexAny(name, "-$"); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// recoverRequest answers the request being handled with an ErrorResponse if
+// the handler panicked, then panics again so that the supervisor decides
+// what happens to the actor. Deferred by every actor that handles requests.
+func (e *engineCore) recoverRequest(context actor.Context) {
+	reason := recover()
+	if reason == nil {
+		return
+	}
+	e.metrics.RecordErrorCode(pb.ErrorCode_INTERNAL.String())
+	if context.Sender() != nil {
+		context.Respond(&pb.ErrorResponse{Error: errInternal, Code: pb.ErrorCode_INTERNAL})
+	}
+	panic(reason)
+}
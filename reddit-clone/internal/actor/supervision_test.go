@@ -0,0 +1,170 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+// panickingStore fails CreatePost the way a nil map write would.
+type panickingStore struct {
+	store.Store
+}
+
+func (p *panickingStore) CreatePost(post *models.Post) error {
+	var byID map[string]*models.Post
+	byID[post.ID] = post
+	return nil
+}
+
+// newPanickingStore holds subreddit s1 with user1 as a member, so that posts
+// reach CreatePost.
+func newPanickingStore() *panickingStore {
+	s := memory.NewMemoryStore()
+	s.CreateSubreddit(&models.Subreddit{ID: "s1", Name: "s1", Members: map[string]bool{"user1": true}})
+	return &panickingStore{Store: s}
+}
+
+// erroringStore fails every vote with an ordinary error.
+type erroringStore struct {
+	store.Store
+}
+
+func (e *erroringStore) Vote(targetID, userID string, isUpvote bool) error {
+	return errors.New("disk full")
+}
+
+func spawnSupervisedEngine(t *testing.T, s store.Store, config SupervisionConfig) (*actor.RootContext, *actor.PID) {
+	t.Helper()
+	m := metrics.NewRedditMetrics()
+	engine := NewEngineActor(s, m)
+	supervisor := NewSupervisor(config, m)
+	system := actor.NewActorSystem()
+	root := system.Root.WithGuardian(supervisor)
+	props := actor.PropsFromProducer(func() actor.Actor { return engine }, actor.WithSupervisor(supervisor))
+	return root, root.Spawn(props)
+}
+
+func restarts(directive string) float64 {
+	return testutil.ToFloat64(metrics.NewRedditMetrics().ActorRestarts.WithLabelValues("subreddit", directive))
+}
+
+func post(token, id string) *pb.PostMessage {
+	return &pb.PostMessage{Id: id, SubredditId: "s1", AuthorId: "user1", Title: id, Token: token}
+}
+
+func TestPanicAnswersRequestAndRestartsActor(t *testing.T) {
+	root, enginePID := spawnSupervisedEngine(t, newPanickingStore(), DefaultSupervision())
+	token := registerAndLogin(t, root, enginePID, "user1")
+	before := restarts("restart")
+
+	result, err := root.RequestFuture(enginePID, post(token, "p1"), 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Expected the panicking request to be answered: %v", err)
+	}
+	if resp, ok := result.(*pb.ErrorResponse); !ok || resp.Error != errInternal {
+		t.Fatalf("Expected an internal error, got %v", result)
+	}
+
+	// The restarted subreddit actor keeps serving the subreddit.
+	result, err = root.RequestFuture(enginePID, &pb.GetFeedMessage{SubredditIds: []string{"s1"}}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to reach the restarted actor: %v", err)
+	}
+	if _, ok := result.(*pb.FeedResponse); !ok {
+		t.Errorf("Expected FeedResponse after the restart, got %v", result)
+	}
+	if got := restarts("restart") - before; got != 1 {
+		t.Errorf("Expected one recorded restart, got %v", got)
+	}
+}
+
+func TestActorIsStoppedAfterMaxRestarts(t *testing.T) {
+	root, enginePID := spawnSupervisedEngine(t, newPanickingStore(), SupervisionConfig{MaxRestarts: 0})
+	token := registerAndLogin(t, root, enginePID, "user1")
+	before := restarts("stop")
+
+	root.RequestFuture(enginePID, post(token, "p1"), 5*time.Second).Result()
+	deadline := time.Now().Add(2 * time.Second)
+	for restarts("stop")-before < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the failing actor to be stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A new actor is spawned for the subreddit once the router has seen the
+	// old one terminate.
+	for {
+		result, err := root.RequestFuture(enginePID, &pb.GetFeedMessage{SubredditIds: []string{"s1"}}, 200*time.Millisecond).Result()
+		if _, ok := result.(*pb.FeedResponse); err == nil && ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected a fresh subreddit actor to answer, got %v (%v)", result, err)
+		}
+	}
+}
+
+func TestRestartBackoffDelaysQueuedRequests(t *testing.T) {
+	const backoff = 300 * time.Millisecond
+	root, enginePID := spawnSupervisedEngine(t, newPanickingStore(), SupervisionConfig{
+		MaxRestarts: 5, Window: time.Minute, Backoff: backoff,
+	})
+	token := registerAndLogin(t, root, enginePID, "user1")
+
+	start := time.Now()
+	root.RequestFuture(enginePID, post(token, "p1"), 5*time.Second).Result()
+	result, err := root.RequestFuture(enginePID, &pb.GetFeedMessage{SubredditIds: []string{"s1"}}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to reach the restarted actor: %v", err)
+	}
+	if _, ok := result.(*pb.FeedResponse); !ok {
+		t.Errorf("Expected FeedResponse after the restart, got %v", result)
+	}
+	if elapsed := time.Since(start); elapsed < backoff {
+		t.Errorf("Expected the restart to wait %v, answered after %v", backoff, elapsed)
+	}
+}
+
+func TestStoreErrorsDoNotRestartActors(t *testing.T) {
+	s := memory.NewMemoryStore()
+	s.CreatePost(&models.Post{ID: "p1", SubredditID: "s1"})
+	root, enginePID := spawnSupervisedEngine(t, &erroringStore{Store: s}, DefaultSupervision())
+	token := registerAndLogin(t, root, enginePID, "user1")
+	before := restarts("restart")
+
+	result, err := root.RequestFuture(enginePID, &pb.VoteMessage{TargetId: "p1", UserId: "user1", IsUpvote: true, Token: token}, 5*time.Second).Result()
+	if err != nil {
+		t.Fatalf("Failed to get response from engine actor: %v", err)
+	}
+	if resp, ok := result.(*pb.ErrorResponse); !ok || resp.Error != "disk full" {
+		t.Errorf("Expected the store's error, got %v", result)
+	}
+	if got := restarts("restart") - before; got != 0 {
+		t.Errorf("Expected no restarts for an ordinary error, got %v", got)
+	}
+}
+
+func TestActorKind(t *testing.T) {
+	tests := map[string]string{
+		"engine":                   "engine",
+		"engine/subreddit-go-nuts": "subreddit",
+		"engine/user-u1":           "user",
+		"engine/worker$12":         "worker",
+	}
+	for id, want := range tests {
+		if got := actorKind(actor.NewPID("nonhost", id)); got != want {
+			t.Errorf("actorKind(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
@@ -1 +1,146 @@
 package actor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/internal/store"
+)
+
+// UserActor handles the requests of one user: registration, login, their
+// profile and account, and the direct messages in their inbox. Because registration and
+// login go through the same actor, a client can send both without waiting
+// for the first answer. It runs as a child of EngineActor or as a cluster
+// grain.
+//
+// It also pushes the user's Notifications to every subscribed actor, such as
+// a client's connection. Subscribers are watched and dropped when they stop;
+// they do not survive a restart of the UserActor. Everything else is in the
+// store, so a UserActor without subscribers stops once it has been idle for
+// userIdle and is started again by the next request.
+type UserActor struct {
+	*engineCore
+	userID      string
+	subscribers map[string]*actor.PID
+}
+
+func (u *UserActor) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *actor.Started:
+		context.SetReceiveTimeout(u.userIdle)
+	case *cluster.ClusterInit:
+		u.userID = msg.Identity.Identity
+	case *actor.ReceiveTimeout:
+		// Poison lets the requests already queued be answered first.
+		if len(u.subscribers) == 0 {
+			context.Poison(context.Self())
+		} else {
+			context.SetReceiveTimeout(u.userIdle)
+		}
+	case *pb.SubscribeMessage:
+		u.handleSubscribe(context, msg)
+	case *pb.UnsubscribeMessage:
+		if context.Sender() != nil {
+			u.unsubscribe(context, context.Sender())
+		}
+		context.Respond(&pb.SuccessResponse{Message: "Unsubscribed successfully"})
+	case *actor.Terminated:
+		delete(u.subscribers, msg.Who.String())
+	case *pb.Notification:
+		u.notify(context, msg)
+	case *pb.KarmaMessage:
+		u.handleKarma(context, msg)
+	case *pb.MessageCopyMessage:
+		u.handleMessageCopy(msg)
+	case *pb.SubscriptionMessage:
+		// Sent without expecting an answer.
+		if err := u.store.SetSubscribed(msg.UserId, msg.SubredditId, msg.Joined); err != nil {
+			u.metrics.RecordError()
+		}
+	default:
+		u.handle(context)
+	}
+}
+
+// handleSubscribe adds the sender to the user's subscribers. It has to be
+// sent with Request from the actor that should receive the notifications.
+// Subscribing twice is a no-op.
+func (u *UserActor) handleSubscribe(context actor.Context, msg *pb.SubscribeMessage) {
+	start := time.Now()
+	if !u.authorize(context, msg.Token, msg.UserId) {
+		return
+	}
+	subscriber := context.Sender()
+	if subscriber == nil {
+		u.metrics.RecordError()
+		return
+	}
+	if u.subscribers == nil {
+		u.subscribers = make(map[string]*actor.PID)
+	}
+	u.subscribers[subscriber.String()] = subscriber
+	context.Watch(subscriber)
+
+	u.metrics.RecordRequest(time.Since(start).Seconds())
+	context.Respond(&pb.SuccessResponse{Message: "Subscribed successfully"})
+}
+
+func (u *UserActor) notify(context actor.Context, notification *pb.Notification) {
+	for _, subscriber := range u.subscribers {
+		context.Send(subscriber, notification)
+	}
+}
+
+// handleKarma credits a vote on one of the user's posts or comments and tells
+// them their new karma, unless they voted themselves. It is sent without
+// expecting an answer.
+func (u *UserActor) handleKarma(context actor.Context, msg *pb.KarmaMessage) {
+	if err := u.store.AddKarma(msg.UserId, msg.PostKarma, msg.CommentKarma); err != nil {
+		u.metrics.RecordError()
+		return
+	}
+	if msg.VoterId == msg.UserId {
+		return
+	}
+	user, err := u.store.GetUser(msg.UserId)
+	if err != nil {
+		return
+	}
+	u.notify(context, &pb.Notification{
+		UserId:      msg.UserId,
+		Kind:        NotifyKarma,
+		ActorId:     msg.VoterId,
+		TargetId:    msg.TargetId,
+		PostId:      msg.PostId,
+		SubredditId: msg.SubredditId,
+		Karma:       user.Karma,
+		CreatedAt:   u.now().Unix(),
+	})
+}
+
+// handleMessageCopy stores or updates the copy of a message the user sent.
+// It is sent without expecting an answer.
+func (u *UserActor) handleMessageCopy(msg *pb.MessageCopyMessage) {
+	message := fromDirectMessage(msg.GetMessage())
+	err := u.store.SendMessage(message)
+	if errors.Is(err, store.ErrAlreadyExists) {
+		err = nil
+		if message.Read {
+			err = u.store.MarkRead(message.ToID, []string{message.ID})
+		}
+	}
+	if err != nil {
+		u.metrics.RecordError()
+	}
+}
+
+func (u *UserActor) unsubscribe(context actor.Context, subscriber *actor.PID) {
+	if _, exists := u.subscribers[subscriber.String()]; exists {
+		delete(u.subscribers, subscriber.String())
+		context.Unwatch(subscriber)
+	}
+}
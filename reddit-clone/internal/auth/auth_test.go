@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHashAndCheckPassword(t *testing.T) {
+	hash, err := HashPassword("password123")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if hash == "password123" || strings.Contains(hash, "password123") {
+		t.Fatal("Expected the hash not to contain the plaintext password")
+	}
+
+	if err := CheckPassword(hash, "password123"); err != nil {
+		t.Errorf("Expected the correct password to match: %v", err)
+	}
+	if err := CheckPassword(hash, "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	tokens := NewTokenManager(GenerateSecret(), time.Hour)
+
+	token, issued, err := tokens.Issue("user1")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	if issued.ExpiresAt <= time.Now().Unix() {
+		t.Errorf("Expected expiry in the future, got %d", issued.ExpiresAt)
+	}
+
+	claims, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if *claims != *issued {
+		t.Errorf("Expected the issued claims %+v, got %+v", issued, claims)
+	}
+	if claims.UserID != "user1" {
+		t.Errorf("Expected token for 'user1', got '%s'", claims.UserID)
+	}
+
+	other, _, _ := tokens.Issue("user1")
+	if otherClaims, _ := tokens.Verify(other); otherClaims.ID == claims.ID {
+		t.Errorf("Expected every token to get its own ID, got %s twice", claims.ID)
+	}
+}
+
+func TestTokenRejectsTamperingAndForeignSecrets(t *testing.T) {
+	tokens := NewTokenManager(GenerateSecret(), time.Hour)
+	token, _, err := tokens.Issue("user1")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	other := NewTokenManager(GenerateSecret(), time.Hour)
+	forged, _, _ := other.Issue("user1")
+
+	payload, signature, _ := strings.Cut(token, ".")
+	for name, candidate := range map[string]string{
+		"empty":          "",
+		"no signature":   payload,
+		"bad signature":  payload + "." + signature[:len(signature)-2] + "xx",
+		"foreign secret": forged,
+	} {
+		if _, err := tokens.Verify(candidate); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	tokens := NewTokenManager(GenerateSecret(), time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	tokens.now = func() time.Time { return now }
+
+	token, _, err := tokens.Issue("user1")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	now = now.Add(59 * time.Second)
+	if _, err := tokens.Verify(token); err != nil {
+		t.Errorf("Expected the token to verify until it expires, got %v", err)
+	}
+	now = now.Add(time.Second)
+	if _, err := tokens.Verify(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// internal/events/events.go
+
+// Package events defines the domain events the engine publishes and sinks
+// that consume them: an NDJSON log, typed in-process subscribers and a
+// recorder for tests. Notifications are built on top of them.
+package events
+
+import "reddit-clone/internal/models"
+
+// Event is a domain event. The engine publishes one on its actor system's
+// EventStream after the store has accepted a write, so subscribers never see
+// an event for a write that failed. Models in events are store snapshots and
+// must not be modified by subscribers.
+type Event interface {
+	// Type names the event in serialized form, e.g. "post_created".
+	Type() string
+}
+
+// UserRegistered carries no password hash, unlike models.User.
+type UserRegistered struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Created  int64  `json:"created"`
+}
+
+// AccountDeleted is published once a user's content has been credited to
+// models.DeletedAuthor.
+type AccountDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+type SubredditCreated struct {
+	Subreddit *models.Subreddit `json:"subreddit"`
+}
+
+type SubredditJoined struct {
+	SubredditID string `json:"subreddit_id"`
+	UserID      string `json:"user_id"`
+}
+
+type SubredditLeft struct {
+	SubredditID string `json:"subreddit_id"`
+	UserID      string `json:"user_id"`
+}
+
+type PostCreated struct {
+	Post *models.Post `json:"post"`
+}
+
+// CommentAdded names the subreddit of the comment's post, which comments do
+// not record themselves.
+type CommentAdded struct {
+	Comment     *models.Comment `json:"comment"`
+	SubredditID string          `json:"subreddit_id"`
+}
+
+// PostEdited and CommentEdited carry the new version; its History holds the
+// earlier ones.
+type PostEdited struct {
+	Post *models.Post `json:"post"`
+}
+
+type CommentEdited struct {
+	Comment     *models.Comment `json:"comment"`
+	SubredditID string          `json:"subreddit_id"`
+}
+
+// PostDeleted and CommentDeleted are published when an author deletes their
+// own content, unlike removals by moderators, which are ModActionTaken.
+type PostDeleted struct {
+	PostID      string `json:"post_id"`
+	SubredditID string `json:"subreddit_id"`
+	AuthorID    string `json:"author_id"`
+}
+
+type CommentDeleted struct {
+	CommentID string `json:"comment_id"`
+	PostID    string `json:"post_id"`
+	AuthorID  string `json:"author_id"`
+}
+
+// VoteCast is published for new and changed votes, and with Cleared set when
+// a vote is retracted.
+type VoteCast struct {
+	TargetID string `json:"target_id"`
+	UserID   string `json:"user_id"`
+	IsUpvote bool   `json:"is_upvote"`
+	Cleared  bool   `json:"cleared"`
+}
+
+type MessageSent struct {
+	Message *models.DirectMessage `json:"message"`
+}
+
+// MessagesRead lists the messages a user has just marked read.
+type MessagesRead struct {
+	UserID     string   `json:"user_id"`
+	MessageIDs []string `json:"message_ids"`
+}
+
+// ModActionTaken is published once a moderation action has been applied and
+// logged.
+type ModActionTaken struct {
+	Action *models.ModAction `json:"action"`
+}
+
+func (*UserRegistered) Type() string   { return "user_registered" }
+func (*AccountDeleted) Type() string   { return "account_deleted" }
+func (*SubredditCreated) Type() string { return "subreddit_created" }
+func (*SubredditJoined) Type() string  { return "subreddit_joined" }
+func (*SubredditLeft) Type() string    { return "subreddit_left" }
+func (*PostCreated) Type() string      { return "post_created" }
+func (*CommentAdded) Type() string     { return "comment_added" }
+func (*PostEdited) Type() string       { return "post_edited" }
+func (*CommentEdited) Type() string    { return "comment_edited" }
+func (*PostDeleted) Type() string      { return "post_deleted" }
+func (*CommentDeleted) Type() string   { return "comment_deleted" }
+func (*VoteCast) Type() string         { return "vote_cast" }
+func (*MessageSent) Type() string      { return "message_sent" }
+func (*MessagesRead) Type() string     { return "messages_read" }
+func (*ModActionTaken) Type() string   { return "mod_action" }
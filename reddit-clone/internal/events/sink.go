@@ -0,0 +1,155 @@
+// internal/events/sink.go
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/eventstream"
+)
+
+// Sink consumes domain events. Sinks are called on the goroutine of the actor
+// that published the event, so they must be quick and safe for concurrent
+// use.
+type Sink interface {
+	Handle(event Event)
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(event Event)
+
+func (f SinkFunc) Handle(event Event) { f(event) }
+
+// Attach subscribes sink to every domain event published on stream. Other
+// messages on the stream, such as dead letters, are skipped.
+//
+// The handlers here filter by type themselves rather than through
+// SubscribeWithPredicate, which sets the predicate after the subscription is
+// visible to concurrent publishers.
+func Attach(stream *eventstream.EventStream, sink Sink) *eventstream.Subscription {
+	return stream.Subscribe(func(evt interface{}) {
+		if event, ok := evt.(Event); ok {
+			sink.Handle(event)
+		}
+	})
+}
+
+// Subscribe calls fn for every event of type T published on stream, e.g.
+//
+//	events.Subscribe(system.EventStream, func(e *events.PostCreated) { ... })
+func Subscribe[T Event](stream *eventstream.EventStream, fn func(event T)) *eventstream.Subscription {
+	return stream.Subscribe(func(evt interface{}) {
+		if event, ok := evt.(T); ok {
+			fn(event)
+		}
+	})
+}
+
+// record is one line of an NDJSON event log.
+type record struct {
+	Type  string    `json:"type"`
+	Time  time.Time `json:"time"`
+	Event Event     `json:"event"`
+}
+
+// NDJSONSink writes every event as one JSON object per line:
+//
+//	{"type":"vote_cast","time":"...","event":{"target_id":"p1",...}}
+type NDJSONSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	now     func() time.Time
+}
+
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{encoder: json.NewEncoder(w), now: time.Now}
+}
+
+// OpenNDJSONFile appends events to the file at path, creating it if needed.
+func OpenNDJSONFile(path string) (*NDJSONSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	sink := NewNDJSONSink(file)
+	sink.closer = file
+	return sink, nil
+}
+
+// Handle writes event. Encoding errors drop the event; an event log is not
+// worth failing a request for.
+func (s *NDJSONSink) Handle(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.encoder.Encode(record{Type: event.Type(), Time: s.now(), Event: event})
+}
+
+// Close closes the file opened by OpenNDJSONFile.
+func (s *NDJSONSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// Recorder is a Sink that keeps every event, for tests.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+	added  chan struct{}
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{added: make(chan struct{}, 1)}
+}
+
+func (r *Recorder) Handle(event Event) {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	select {
+	case r.added <- struct{}{}:
+	default:
+	}
+}
+
+// Events returns the events recorded so far, oldest first.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Types returns the Type of every recorded event, oldest first.
+func (r *Recorder) Types() []string {
+	var types []string
+	for _, event := range r.Events() {
+		types = append(types, event.Type())
+	}
+	return types
+}
+
+// WaitFor waits until at least n events have been recorded and reports
+// whether that happened within timeout.
+func (r *Recorder) WaitFor(n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		r.mu.Lock()
+		count := len(r.events)
+		r.mu.Unlock()
+		if count >= n {
+			return true
+		}
+		select {
+		case <-r.added:
+		case <-deadline:
+			return false
+		}
+	}
+}
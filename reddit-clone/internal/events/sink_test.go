@@ -0,0 +1,64 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/eventstream"
+
+	"reddit-clone/internal/models"
+)
+
+func TestNDJSONSinkWritesOneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewNDJSONSink(&buf)
+	sink.now = func() time.Time { return time.Unix(100, 0).UTC() }
+
+	sink.Handle(&PostCreated{Post: &models.Post{ID: "p1", Title: "Hello"}})
+	sink.Handle(&VoteCast{TargetID: "p1", UserID: "u2", IsUpvote: true})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	var vote struct {
+		Type  string    `json:"type"`
+		Time  time.Time `json:"time"`
+		Event VoteCast  `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &vote); err != nil {
+		t.Fatalf("Failed to decode %q: %v", lines[1], err)
+	}
+	if vote.Type != "vote_cast" || vote.Time.Unix() != 100 || vote.Event.TargetID != "p1" || !vote.Event.IsUpvote {
+		t.Errorf("Unexpected record: %+v", vote)
+	}
+	if !strings.Contains(lines[0], `"type":"post_created"`) {
+		t.Errorf("Expected a post_created record, got %s", lines[0])
+	}
+}
+
+func TestSubscribeFiltersByType(t *testing.T) {
+	stream := eventstream.NewEventStream()
+	var posts []string
+	sub := Subscribe(stream, func(event *PostCreated) {
+		posts = append(posts, event.Post.ID)
+	})
+	recorder := NewRecorder()
+	Attach(stream, recorder)
+
+	stream.Publish(&PostCreated{Post: &models.Post{ID: "p1"}})
+	stream.Publish(&SubredditJoined{SubredditID: "s1", UserID: "u1"})
+	stream.Publish("not an event")
+	stream.Unsubscribe(sub)
+	stream.Publish(&PostCreated{Post: &models.Post{ID: "p2"}})
+
+	if len(posts) != 1 || posts[0] != "p1" {
+		t.Errorf("Expected only p1 before unsubscribing, got %v", posts)
+	}
+	if got := strings.Join(recorder.Types(), ","); got != "post_created,subreddit_joined,post_created" {
+		t.Errorf("Expected every domain event to be recorded, got %s", got)
+	}
+}
@@ -0,0 +1,90 @@
+// internal/gateway/events.go
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	pb "reddit-clone/api/proto/generated"
+)
+
+const (
+	// eventBuffer is how many notifications may wait for a slow client
+	// before further ones are dropped.
+	eventBuffer = 64
+	// keepAliveInterval keeps idle streams from being closed by proxies.
+	keepAliveInterval = 30 * time.Second
+)
+
+// handleEvents streams a user's notifications as server-sent events. Each
+// event is named after the notification's kind and carries it as JSON:
+//
+//	event: reply
+//	data: {"user_id":"bob","kind":"reply",...}
+//
+// The stream is backed by an actor subscribed to the user's UserActor, which
+// stops, and so unsubscribes, when the client disconnects.
+func (g *Gateway) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	notifications := make(chan *pb.Notification, eventBuffer)
+	subscribed := make(chan interface{}, 1)
+	subscribe := &pb.SubscribeMessage{UserId: r.PathValue("id"), Token: bearerToken(r)}
+	subscriber := g.root.Spawn(actor.PropsFromFunc(func(context actor.Context) {
+		switch msg := context.Message().(type) {
+		case *actor.Started:
+			context.Request(g.enginePID, subscribe)
+		case *pb.SuccessResponse, *pb.ErrorResponse:
+			subscribed <- msg
+		case *pb.Notification:
+			select {
+			case notifications <- msg:
+			default:
+			}
+		}
+	}))
+	defer g.root.Stop(subscriber)
+
+	select {
+	case result := <-subscribed:
+		if errResp, ok := result.(*pb.ErrorResponse); ok {
+			writeProto(w, statusForError(errResp), errResp)
+			return
+		}
+	case <-time.After(g.timeout):
+		writeError(w, http.StatusGatewayTimeout, "engine did not respond in time")
+		return
+	case <-r.Context().Done():
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case notification := <-notifications:
+			data, err := marshalOptions.Marshal(notification)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Kind, data)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
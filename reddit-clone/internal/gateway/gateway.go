@@ -0,0 +1,123 @@
+// internal/gateway/gateway.go
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "reddit-clone/api/proto/generated"
+)
+
+// Gateway exposes the engine's protobuf protocol as a REST/JSON API. Every
+// HTTP request is translated into a pb.* message and sent to the engine with
+// RequestFuture, so the gateway holds no state of its own apart from one
+// subscriber actor per open event stream (see events.go).
+type Gateway struct {
+	root      *actor.RootContext
+	enginePID *actor.PID
+	timeout   time.Duration
+	mux       *http.ServeMux
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+func NewGateway(root *actor.RootContext, enginePID *actor.PID, timeout time.Duration) *Gateway {
+	g := &Gateway{
+		root:      root,
+		enginePID: enginePID,
+		timeout:   timeout,
+		mux:       http.NewServeMux(),
+	}
+	g.registerRoutes()
+	return g
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// request sends msg to the engine and waits for its reply. Transport failures
+// are written to w directly and reported as ok == false.
+func (g *Gateway) request(w http.ResponseWriter, msg proto.Message) (interface{}, bool) {
+	result, err := g.root.RequestFuture(g.enginePID, msg, g.timeout).Result()
+	if err != nil {
+		if errors.Is(err, actor.ErrTimeout) {
+			writeError(w, http.StatusGatewayTimeout, "engine did not respond in time")
+		} else {
+			writeError(w, http.StatusBadGateway, err.Error())
+		}
+		return nil, false
+	}
+
+	if errResp, ok := result.(*pb.ErrorResponse); ok {
+		writeProto(w, statusForError(errResp), errResp)
+		return nil, false
+	}
+	return result, true
+}
+
+// statusForError maps the code of an engine error onto an HTTP status code.
+func statusForError(resp *pb.ErrorResponse) int {
+	switch resp.GetCode() {
+	case pb.ErrorCode_NOT_FOUND:
+		return http.StatusNotFound
+	case pb.ErrorCode_ALREADY_EXISTS:
+		return http.StatusConflict
+	case pb.ErrorCode_FORBIDDEN:
+		return http.StatusForbidden
+	case pb.ErrorCode_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case pb.ErrorCode_INTERNAL:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// decodeBody reads a JSON request body into msg. An empty body leaves msg
+// untouched so that path parameters alone can describe a request.
+func decodeBody(r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	return unmarshalOptions.Unmarshal(body, msg)
+}
+
+func writeProto(w http.ResponseWriter, status int, msg proto.Message) {
+	body, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("gateway: failed to encode response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,557 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	internalActor "reddit-clone/internal/actor"
+	"reddit-clone/internal/store/memory"
+	"reddit-clone/pkg/metrics"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	system := actor.NewActorSystem()
+	engine := internalActor.NewEngineActor(memory.NewMemoryStore(), metrics.NewRedditMetrics())
+	props := actor.PropsFromProducer(func() actor.Actor { return engine })
+	enginePID, err := system.Root.SpawnNamed(props, "engine")
+	if err != nil {
+		t.Fatalf("Failed to spawn engine actor: %v", err)
+	}
+
+	server := httptest.NewServer(NewGateway(system.Root, enginePID, 5*time.Second))
+	t.Cleanup(func() {
+		server.Close()
+		system.Shutdown()
+	})
+	return server
+}
+
+func doJSON(t *testing.T, server *httptest.Server, method, path, token, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request %s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	decoded := make(map[string]interface{})
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		t.Fatalf("Failed to decode response of %s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, decoded
+}
+
+// registerAndLogin creates a user through the API and returns its token.
+func registerAndLogin(t *testing.T, server *httptest.Server, userID string) string {
+	t.Helper()
+	body := `{"user_id":"` + userID + `","username":"` + userID + `","password":"secret"}`
+	if status, resp := doJSON(t, server, "POST", "/api/users", "", body); status != http.StatusCreated {
+		t.Fatalf("Expected status 201 registering %s, got %d (%v)", userID, status, resp)
+	}
+	status, resp := doJSON(t, server, "POST", "/api/login", "", `{"user_id":"`+userID+`","password":"secret"}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 logging in %s, got %d (%v)", userID, status, resp)
+	}
+	token, _ := resp["token"].(string)
+	if token == "" {
+		t.Fatalf("Expected a session token for %s, got %v", userID, resp)
+	}
+	return token
+}
+
+func TestGatewayPostLifecycle(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+
+	steps := []struct {
+		method, path, body string
+		status             int
+	}{
+		{"POST", "/api/subreddits", `{"id":"golang","name":"golang","creator_id":"alice"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/join", `{"user_id":"alice"}`, http.StatusOK},
+		{"POST", "/api/subreddits/golang/posts", `{"id":"p1","author_id":"alice","title":"Hello","content":"World"}`, http.StatusCreated},
+		{"POST", "/api/posts/p1/comments", `{"id":"c1","author_id":"alice","content":"First"}`, http.StatusCreated},
+		{"POST", "/api/posts/p1/comments", `{"id":"c2","parent_id":"c1","author_id":"alice","content":"Reply"}`, http.StatusCreated},
+		{"POST", "/api/votes", `{"target_id":"p1","user_id":"alice","is_upvote":true}`, http.StatusOK},
+		{"POST", "/api/subreddits/golang/leave", `{"user_id":"alice"}`, http.StatusOK},
+	}
+	for _, step := range steps {
+		status, body := doJSON(t, server, step.method, step.path, token, step.body)
+		if status != step.status {
+			t.Fatalf("%s %s: expected status %d, got %d (%v)", step.method, step.path, step.status, status, body)
+		}
+	}
+
+	status, members := doJSON(t, server, "GET", "/api/subreddits/golang/members", "", "")
+	if status != http.StatusOK || members["total"] != 0.0 {
+		t.Errorf("Expected no members after alice left, got %d (%v)", status, members)
+	}
+	status, subscriptions := doJSON(t, server, "GET", "/api/users/alice/subscriptions", "", "")
+	if list, _ := subscriptions["subreddits"].([]interface{}); status != http.StatusOK || len(list) != 0 {
+		t.Errorf("Expected no subscriptions after alice left, got %d (%v)", status, subscriptions)
+	}
+
+	status, feed := doJSON(t, server, "GET", "/api/feed?subreddit_id=golang", "", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 for feed, got %d", status)
+	}
+	posts, _ := feed["posts"].([]interface{})
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 post in feed, got %d", len(posts))
+	}
+	if title := posts[0].(map[string]interface{})["title"]; title != "Hello" {
+		t.Errorf("Expected post title 'Hello', got '%v'", title)
+	}
+
+	status, comments := doJSON(t, server, "GET", "/api/posts/p1/comments", "", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 for comments, got %d", status)
+	}
+	if list, _ := comments["comments"].([]interface{}); len(list) != 2 {
+		t.Errorf("Expected 2 comments, got %d", len(list))
+	}
+	tree, _ := comments["tree"].([]interface{})
+	if len(tree) != 1 {
+		t.Fatalf("Expected 1 top-level comment, got %d", len(tree))
+	}
+	if replies, _ := tree[0].(map[string]interface{})["replies"].([]interface{}); len(replies) != 1 {
+		t.Errorf("Expected the reply nested under c1, got %v", tree[0])
+	}
+}
+
+func TestGatewayDirectMessages(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+	bob := registerAndLogin(t, server, "bob")
+
+	status, created := doJSON(t, server, "POST", "/api/messages", token, `{"from_id":"alice","to_id":"bob","content":"hi bob"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d (%v)", status, created)
+	}
+	if created["id"] == "" {
+		t.Error("Expected the gateway to assign a message ID")
+	}
+
+	if status, resp := doJSON(t, server, "GET", "/api/users/bob/messages", token, ""); status != http.StatusForbidden {
+		t.Errorf("Expected status 403 reading bob's inbox as alice, got %d (%v)", status, resp)
+	}
+	if status, resp := doJSON(t, server, "GET", "/api/users/bob/conversations", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 listing conversations without a session, got %d (%v)", status, resp)
+	}
+
+	status, inbox := doJSON(t, server, "GET", "/api/users/bob/messages", bob, "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	messages, _ := inbox["messages"].([]interface{})
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if content := messages[0].(map[string]interface{})["content"]; content != "hi bob" {
+		t.Errorf("Expected content 'hi bob', got '%v'", content)
+	}
+
+	status, conversations := doJSON(t, server, "GET", "/api/users/bob/conversations", bob, "")
+	if status != http.StatusOK || conversations["unread"] != 1.0 {
+		t.Fatalf("Expected 1 unread message, got %d (%v)", status, conversations)
+	}
+	if status, resp := doJSON(t, server, "POST", "/api/users/bob/messages/read", bob, `{"with_id":"alice"}`); status != http.StatusOK {
+		t.Fatalf("Expected status 200 marking messages read, got %d (%v)", status, resp)
+	}
+	_, thread := doJSON(t, server, "GET", "/api/users/bob/messages?with=alice", bob, "")
+	messages, _ = thread["messages"].([]interface{})
+	if len(messages) != 1 || messages[0].(map[string]interface{})["read"] != true {
+		t.Errorf("Expected the thread with alice to be read, got %v", thread)
+	}
+}
+
+func TestGatewayFeedCursors(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+	if status, resp := doJSON(t, server, "POST", "/api/subreddits", token, `{"id":"golang","name":"golang","creator_id":"alice"}`); status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+	}
+
+	for _, id := range []string{"p1", "p2", "p3"} {
+		body := `{"id":"` + id + `","author_id":"alice","title":"` + id + `"}`
+		if status, resp := doJSON(t, server, "POST", "/api/subreddits/golang/posts", token, body); status != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+		}
+	}
+
+	seen := make(map[string]bool)
+	path := "/api/feed?subreddit_id=golang&sort=new&limit=2"
+	for pages := 0; path != ""; pages++ {
+		if pages > 3 {
+			t.Fatal("Expected paging to end")
+		}
+		status, feed := doJSON(t, server, "GET", path, "", "")
+		if status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d (%v)", status, feed)
+		}
+		posts, _ := feed["posts"].([]interface{})
+		for _, post := range posts {
+			id := post.(map[string]interface{})["id"].(string)
+			if seen[id] {
+				t.Errorf("Post %s returned on two pages", id)
+			}
+			seen[id] = true
+		}
+		path = ""
+		if next, _ := feed["next_cursor"].(string); next != "" {
+			path = "/api/feed?subreddit_id=golang&sort=new&limit=2&after=" + next
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected to page through 3 posts, saw %d", len(seen))
+	}
+
+	if status, _ := doJSON(t, server, "GET", "/api/feed?subreddit_id=golang&after=bogus", "", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a malformed cursor, got %d", status)
+	}
+}
+
+func TestGatewayHomeAndAllFeeds(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+
+	steps := []struct {
+		path, body string
+		status     int
+	}{
+		{"/api/subreddits", `{"id":"golang","name":"golang","creator_id":"alice"}`, http.StatusCreated},
+		{"/api/subreddits", `{"id":"rust","name":"rust","creator_id":"alice"}`, http.StatusCreated},
+		{"/api/subreddits/golang/join", `{"user_id":"alice"}`, http.StatusOK},
+		{"/api/subreddits/golang/posts", `{"id":"p1","author_id":"alice","title":"Go"}`, http.StatusCreated},
+		{"/api/subreddits/rust/posts", `{"id":"p2","author_id":"alice","title":"Rust"}`, http.StatusCreated},
+		{"/api/votes", `{"target_id":"p2","user_id":"alice","is_upvote":true}`, http.StatusOK},
+	}
+	for _, step := range steps {
+		if status, resp := doJSON(t, server, "POST", step.path, token, step.body); status != step.status {
+			t.Fatalf("POST %s: expected status %d, got %d (%v)", step.path, step.status, status, resp)
+		}
+	}
+
+	feeds := map[string]int{
+		"/api/users/alice/feed": 1,
+		"/api/feed/all":         2,
+		"/api/feed/popular":     1,
+	}
+	for path, want := range feeds {
+		status, feed := doJSON(t, server, "GET", path, "", "")
+		if status != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d (%v)", path, status, feed)
+		}
+		if posts, _ := feed["posts"].([]interface{}); len(posts) != want {
+			t.Errorf("GET %s: expected %d posts, got %v", path, want, feed)
+		}
+	}
+}
+
+func TestGatewayErrorStatusCodes(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+
+	user := `{"user_id":"alice","username":"alice","password":"secret"}`
+	if status, body := doJSON(t, server, "POST", "/api/users", "", user); status != http.StatusConflict {
+		t.Errorf("Expected status 409 for duplicate user, got %d (%v)", status, body)
+	}
+
+	if status, body := doJSON(t, server, "POST", "/api/subreddits/missing/join", token, `{"user_id":"alice"}`); status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown subreddit, got %d (%v)", status, body)
+	}
+	status, body := doJSON(t, server, "POST", "/api/posts/missing/comments", token, `{"author_id":"alice"}`)
+	if status != http.StatusNotFound {
+		t.Errorf("Expected status 404 commenting on an unknown post, got %d (%v)", status, body)
+	}
+	details, _ := body["details"].(map[string]interface{})
+	if body["code"] != "NOT_FOUND" || details["kind"] != "post" || details["id"] != "missing" {
+		t.Errorf("Expected code and details of the missing post, got %v", body)
+	}
+
+	if status, body := doJSON(t, server, "POST", "/api/login", "", `{"user_id":"alice","password":"wrong"}`); status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for a wrong password, got %d (%v)", status, body)
+	}
+
+	post := `{"author_id":"alice","title":"Hello"}`
+	if status, body := doJSON(t, server, "POST", "/api/subreddits/golang/posts", "", post); status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without a token, got %d (%v)", status, body)
+	}
+	impersonation := `{"author_id":"bob","title":"Hello"}`
+	if status, body := doJSON(t, server, "POST", "/api/subreddits/golang/posts", token, impersonation); status != http.StatusForbidden {
+		t.Errorf("Expected status 403 posting as another user, got %d (%v)", status, body)
+	}
+
+	if status, _ := doJSON(t, server, "POST", "/api/users", "", `{not json`); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for malformed body, got %d", status)
+	}
+
+	if status, _ := doJSON(t, server, "GET", "/api/feed?limit=abc", "", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid limit, got %d", status)
+	}
+}
+
+func TestGatewayEventStream(t *testing.T) {
+	server := newTestServer(t)
+	aliceToken := registerAndLogin(t, server, "alice")
+	bobToken := registerAndLogin(t, server, "bob")
+
+	if status, body := doJSON(t, server, "GET", "/api/users/bob/events", aliceToken, ""); status != http.StatusForbidden {
+		t.Errorf("Expected status 403 streaming another user's events, got %d (%v)", status, body)
+	}
+
+	req, err := http.NewRequest("GET", server.URL+"/api/users/bob/events", nil)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+bobToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected a 200 event stream, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	if status, body := doJSON(t, server, "POST", "/api/messages", aliceToken, `{"from_id":"alice","to_id":"bob","content":"hi bob"}`); status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d (%v)", status, body)
+	}
+
+	events := make(chan [2]string, 1)
+	go func() {
+		reader := bufio.NewReader(resp.Body)
+		var name string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if value, ok := strings.CutPrefix(line, "event: "); ok {
+				name = strings.TrimSpace(value)
+			} else if value, ok := strings.CutPrefix(line, "data: "); ok {
+				events <- [2]string{name, value}
+				return
+			}
+		}
+	}()
+
+	select {
+	case event := <-events:
+		notification := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(event[1]), &notification); err != nil {
+			t.Fatalf("Failed to decode event data %q: %v", event[1], err)
+		}
+		if event[0] != "message" || notification["actor_id"] != "alice" || notification["content"] != "hi bob" {
+			t.Errorf("Unexpected event %s: %v", event[0], notification)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a message event")
+	}
+}
+
+func TestGatewaySearch(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+	if status, resp := doJSON(t, server, "POST", "/api/subreddits", token, `{"id":"programming","name":"programming","creator_id":"alice"}`); status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+	}
+
+	for _, title := range []string{"Learning Go", "Go generics", "Rust ownership"} {
+		body := `{"author_id":"alice","title":"` + title + `"}`
+		if status, resp := doJSON(t, server, "POST", "/api/subreddits/programming/posts", token, body); status != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+		}
+	}
+
+	status, resp := doJSON(t, server, "GET", "/api/search?q=go&kind=post&author_id=alice", "", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d (%v)", status, resp)
+	}
+	results, _ := resp["results"].([]interface{})
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results, got %v", resp)
+	}
+
+	if status, _ := doJSON(t, server, "GET", "/api/search?q=", "", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an empty query, got %d", status)
+	}
+	if status, _ := doJSON(t, server, "GET", "/api/search?q=go&created_after=yesterday", "", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid time, got %d", status)
+	}
+}
+
+func TestGatewayModeration(t *testing.T) {
+	server := newTestServer(t)
+	alice := registerAndLogin(t, server, "alice")
+	bob := registerAndLogin(t, server, "bob")
+
+	steps := []struct {
+		method, path, token, body string
+		status                    int
+	}{
+		{"POST", "/api/subreddits", alice, `{"id":"golang","name":"golang","creator_id":"alice"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/posts", bob, `{"id":"p1","author_id":"bob","title":"Spam"}`, http.StatusForbidden},
+		{"POST", "/api/subreddits/golang/join", bob, `{"user_id":"bob"}`, http.StatusOK},
+		{"POST", "/api/subreddits/golang/posts", bob, `{"id":"p1","author_id":"bob","title":"Spam"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/posts", bob, `{"id":"p2","author_id":"bob","title":"Hello"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/bans", bob, `{"user_id":"alice","moderator_id":"bob"}`, http.StatusForbidden},
+		{"POST", "/api/posts/p1/remove", alice, `{"moderator_id":"alice","reason":"spam"}`, http.StatusOK},
+		{"POST", "/api/posts/p2/lock", alice, `{"moderator_id":"alice"}`, http.StatusOK},
+		{"POST", "/api/posts/p2/comments", alice, `{"author_id":"alice","content":"Late"}`, http.StatusForbidden},
+		{"POST", "/api/subreddits/golang/bans", alice, `{"user_id":"bob","moderator_id":"alice"}`, http.StatusOK},
+		{"POST", "/api/subreddits/golang/posts", bob, `{"author_id":"bob","title":"Again"}`, http.StatusForbidden},
+		{"POST", "/api/subreddits/golang/moderators", alice, `{"user_id":"carol","moderator_id":"alice"}`, http.StatusOK},
+	}
+	for _, step := range steps {
+		status, body := doJSON(t, server, step.method, step.path, step.token, step.body)
+		if status != step.status {
+			t.Fatalf("%s %s: expected status %d, got %d (%v)", step.method, step.path, step.status, status, body)
+		}
+	}
+
+	status, resp := doJSON(t, server, "GET", "/api/subreddits/golang/modlog?limit=10", "", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 for the mod log, got %d (%v)", status, resp)
+	}
+	if actions, _ := resp["actions"].([]interface{}); len(actions) != 4 {
+		t.Errorf("Expected 4 logged actions, got %v", resp)
+	}
+}
+
+func TestGatewayEditAndDelete(t *testing.T) {
+	server := newTestServer(t)
+	alice := registerAndLogin(t, server, "alice")
+	bob := registerAndLogin(t, server, "bob")
+
+	steps := []struct {
+		method, path, token, body string
+		status                    int
+	}{
+		{"POST", "/api/subreddits", alice, `{"id":"golang","name":"golang","creator_id":"alice"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/posts", alice, `{"id":"p1","author_id":"alice","title":"Hello","content":"v1"}`, http.StatusCreated},
+		{"POST", "/api/posts/p1/comments", alice, `{"id":"c1","author_id":"alice","content":"first"}`, http.StatusCreated},
+		{"PATCH", "/api/posts/p1", bob, `{"author_id":"bob","content":"hacked"}`, http.StatusForbidden},
+		{"PATCH", "/api/posts/p1", alice, `{"author_id":"alice","content":"v2"}`, http.StatusOK},
+		{"PATCH", "/api/comments/c1", alice, `{"author_id":"alice","content":"second"}`, http.StatusOK},
+		{"DELETE", "/api/comments/c1", alice, `{"author_id":"alice"}`, http.StatusOK},
+		{"GET", "/api/comments/c1/history", "", "", http.StatusNotFound},
+		{"DELETE", "/api/posts/missing", alice, `{"author_id":"alice"}`, http.StatusNotFound},
+	}
+	for _, step := range steps {
+		status, body := doJSON(t, server, step.method, step.path, step.token, step.body)
+		if status != step.status {
+			t.Fatalf("%s %s: expected status %d, got %d (%v)", step.method, step.path, step.status, status, body)
+		}
+	}
+
+	status, resp := doJSON(t, server, "GET", "/api/posts/p1/history", "", "")
+	if revisions, _ := resp["revisions"].([]interface{}); status != http.StatusOK || len(revisions) != 2 {
+		t.Errorf("Expected 2 revisions of p1, got %d (%v)", status, resp)
+	}
+}
+
+func TestGatewayAccountLifecycle(t *testing.T) {
+	server := newTestServer(t)
+	alice := registerAndLogin(t, server, "alice")
+	bob := registerAndLogin(t, server, "bob")
+
+	steps := []struct {
+		method, path, token, body string
+		status                    int
+	}{
+		{"PUT", "/api/users/alice/profile", alice, `{"display_name":"Alice","bio":"Gopher"}`, http.StatusOK},
+		{"PUT", "/api/users/alice/profile", bob, `{"display_name":"Bob"}`, http.StatusForbidden},
+		{"POST", "/api/subreddits", alice, `{"id":"golang","name":"golang","creator_id":"alice"}`, http.StatusCreated},
+		{"POST", "/api/subreddits/golang/posts", alice, `{"id":"p1","author_id":"alice","title":"Hello"}`, http.StatusCreated},
+		{"POST", "/api/users/alice/password", alice, `{"password":"wrong","new_password":"better"}`, http.StatusUnauthorized},
+		{"GET", "/api/users/alice/export", bob, "", http.StatusForbidden},
+	}
+	for _, step := range steps {
+		status, body := doJSON(t, server, step.method, step.path, step.token, step.body)
+		if status != step.status {
+			t.Fatalf("%s %s: expected status %d, got %d (%v)", step.method, step.path, step.status, status, body)
+		}
+	}
+
+	status, resp := doJSON(t, server, "POST", "/api/users/alice/password", alice, `{"password":"secret","new_password":"better"}`)
+	fresh, _ := resp["token"].(string)
+	if status != http.StatusOK || fresh == "" {
+		t.Fatalf("Expected a new session token, got %d (%v)", status, resp)
+	}
+	if status, resp := doJSON(t, server, "GET", "/api/users/alice/export", alice, ""); status != http.StatusUnauthorized {
+		t.Errorf("Expected the old token to be revoked, got %d (%v)", status, resp)
+	}
+
+	status, resp = doJSON(t, server, "GET", "/api/users/alice/export", fresh, "")
+	user, _ := resp["user"].(map[string]interface{})
+	posts, _ := resp["posts"].([]interface{})
+	if status != http.StatusOK || user["DisplayName"] != "Alice" || user["Password"] != "" || len(posts) != 1 {
+		t.Errorf("Expected alice's archive, got %d (%v)", status, resp)
+	}
+
+	if status, resp := doJSON(t, server, "DELETE", "/api/users/alice", fresh, `{"password":"better"}`); status != http.StatusOK {
+		t.Fatalf("Expected status 200 deleting alice, got %d (%v)", status, resp)
+	}
+	status, resp = doJSON(t, server, "GET", "/api/users/alice", "", "")
+	if status != http.StatusOK || resp["deleted"] != true || resp["username"] != "" {
+		t.Errorf("Expected a deleted profile, got %d (%v)", status, resp)
+	}
+	if status, resp := doJSON(t, server, "POST", "/api/login", "", `{"user_id":"alice","password":"better"}`); status != http.StatusUnauthorized {
+		t.Errorf("Expected a deleted account not to log in, got %d (%v)", status, resp)
+	}
+}
+
+func TestGatewaySubredditDiscovery(t *testing.T) {
+	server := newTestServer(t)
+	token := registerAndLogin(t, server, "alice")
+
+	for _, body := range []string{
+		`{"id":"go","name":"golang","creator_id":"alice"}`,
+		`{"id":"rs","name":"rust","creator_id":"alice"}`,
+	} {
+		if status, resp := doJSON(t, server, "POST", "/api/subreddits", token, body); status != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+		}
+	}
+	if status, resp := doJSON(t, server, "POST", "/api/subreddits", token, `{"name":"GoLang","creator_id":"alice"}`); status != http.StatusConflict {
+		t.Errorf("Expected status 409 for a taken name, got %d (%v)", status, resp)
+	}
+	body := `{"author_id":"alice","title":"Ownership"}`
+	if status, resp := doJSON(t, server, "POST", "/api/subreddits/rs/posts", token, body); status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d (%v)", status, resp)
+	}
+
+	status, resp := doJSON(t, server, "GET", "/api/r/Rust", "", "")
+	if status != http.StatusOK || resp["id"] != "rs" {
+		t.Errorf("Expected rust by name, got %d (%v)", status, resp)
+	}
+	if status, resp := doJSON(t, server, "GET", "/api/subreddits/go", "", ""); status != http.StatusOK || resp["name"] != "golang" {
+		t.Errorf("Expected golang by ID, got %d (%v)", status, resp)
+	}
+
+	status, resp = doJSON(t, server, "GET", "/api/subreddits?sort=active&limit=1", "", "")
+	list, _ := resp["subreddits"].([]interface{})
+	if status != http.StatusOK || len(list) != 1 || list[0].(map[string]interface{})["id"] != "rs" || resp["next_cursor"] == nil {
+		t.Errorf("Expected rust first with a next cursor, got %d (%v)", status, resp)
+	}
+
+	status, resp = doJSON(t, server, "GET", "/api/subreddits/trending", "", "")
+	trending, _ := resp["subreddits"].([]interface{})
+	if status != http.StatusOK || len(trending) != 1 || trending[0].(map[string]interface{})["id"] != "rs" {
+		t.Errorf("Expected only rust to trend, got %d (%v)", status, resp)
+	}
+}
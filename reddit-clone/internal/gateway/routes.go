@@ -0,0 +1,614 @@
+// internal/gateway/routes.go
+package gateway
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "reddit-clone/api/proto/generated"
+	"reddit-clone/pkg/utils"
+)
+
+// createdResponse is returned for every successful write. Writes that create
+// something carry its ID so clients learn the one the gateway assigned when
+// they did not supply one.
+type createdResponse struct {
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+func (g *Gateway) registerRoutes() {
+	g.mux.HandleFunc("POST /api/users", g.handleRegisterUser)
+	g.mux.HandleFunc("POST /api/login", g.handleLogin)
+	g.mux.HandleFunc("POST /api/logout", g.handleLogout)
+	g.mux.HandleFunc("GET /api/users/{id}", g.handleGetUserProfile)
+	g.mux.HandleFunc("PUT /api/users/{id}/profile", g.handleUpdateProfile)
+	g.mux.HandleFunc("POST /api/users/{id}/password", g.handleChangePassword)
+	g.mux.HandleFunc("DELETE /api/users/{id}", g.handleDeleteAccount)
+	g.mux.HandleFunc("GET /api/users/{id}/export", g.handleExportUserData)
+	g.mux.HandleFunc("GET /api/users/{id}/messages", g.handleGetMessages)
+	g.mux.HandleFunc("POST /api/users/{id}/messages/read", g.handleMarkRead)
+	g.mux.HandleFunc("GET /api/users/{id}/conversations", g.handleGetConversations)
+	g.mux.HandleFunc("GET /api/users/{id}/feed", g.handleGetHomeFeed)
+	g.mux.HandleFunc("GET /api/users/{id}/subscriptions", g.handleGetSubscriptions)
+	g.mux.HandleFunc("GET /api/users/{id}/events", g.handleEvents)
+
+	g.mux.HandleFunc("POST /api/subreddits", g.handleCreateSubreddit)
+	g.mux.HandleFunc("GET /api/subreddits", g.handleListSubreddits)
+	g.mux.HandleFunc("GET /api/subreddits/trending", g.handleGetTrendingSubreddits)
+	g.mux.HandleFunc("GET /api/subreddits/{id}", g.handleGetSubreddit)
+	g.mux.HandleFunc("GET /api/r/{name}", g.handleGetSubredditByName)
+	g.mux.HandleFunc("POST /api/subreddits/{id}/join", g.handleJoinSubreddit)
+	g.mux.HandleFunc("POST /api/subreddits/{id}/leave", g.handleLeaveSubreddit)
+	g.mux.HandleFunc("GET /api/subreddits/{id}/members", g.handleGetSubredditMembers)
+	g.mux.HandleFunc("POST /api/subreddits/{id}/posts", g.handleCreatePost)
+	g.mux.HandleFunc("GET /api/subreddits/{id}/posts", g.handleGetSubredditPosts)
+	g.mux.HandleFunc("POST /api/subreddits/{id}/moderators", g.handleAddModerator)
+	g.mux.HandleFunc("POST /api/subreddits/{id}/bans", g.handleBanUser)
+	g.mux.HandleFunc("GET /api/subreddits/{id}/modlog", g.handleGetModLog)
+
+	g.mux.HandleFunc("POST /api/posts/{id}/comments", g.handleCreateComment)
+	g.mux.HandleFunc("GET /api/posts/{id}/comments", g.handleGetComments)
+	g.mux.HandleFunc("POST /api/posts/{id}/remove", g.handleRemoveContent)
+	g.mux.HandleFunc("POST /api/posts/{id}/lock", g.handleLockPost)
+	g.mux.HandleFunc("POST /api/comments/{id}/remove", g.handleRemoveContent)
+	g.mux.HandleFunc("PATCH /api/posts/{id}", g.handleEditPost)
+	g.mux.HandleFunc("DELETE /api/posts/{id}", g.handleDeletePost)
+	g.mux.HandleFunc("GET /api/posts/{id}/history", g.handleGetEditHistory)
+	g.mux.HandleFunc("PATCH /api/comments/{id}", g.handleEditComment)
+	g.mux.HandleFunc("DELETE /api/comments/{id}", g.handleDeleteComment)
+	g.mux.HandleFunc("GET /api/comments/{id}/history", g.handleGetEditHistory)
+
+	g.mux.HandleFunc("POST /api/votes", g.handleVote)
+	g.mux.HandleFunc("GET /api/feed", g.handleGetFeed)
+	g.mux.HandleFunc("GET /api/feed/all", g.handleGetAllFeed)
+	g.mux.HandleFunc("GET /api/feed/popular", g.handleGetAllFeed)
+	g.mux.HandleFunc("POST /api/messages", g.handleSendMessage)
+	g.mux.HandleFunc("GET /api/search", g.handleSearch)
+}
+
+func (g *Gateway) handleRegisterUser(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.UserMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	if msg.UserId == "" {
+		msg.UserId = utils.GenerateID()
+	}
+	g.create(w, msg.UserId, msg)
+}
+
+func (g *Gateway) handleLogin(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.LoginMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	g.read(w, msg)
+}
+
+func (g *Gateway) handleLogout(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.LogoutMessage{Token: bearerToken(r)})
+}
+
+func (g *Gateway) handleGetUserProfile(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.GetUserProfileMessage{UserId: r.PathValue("id")})
+}
+
+func (g *Gateway) handleUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.UpdateProfileMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.UserId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+// handleChangePassword answers with the new session token, since the one the
+// request was made with is revoked.
+func (g *Gateway) handleChangePassword(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.ChangePasswordMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.UserId = r.PathValue("id")
+	g.read(w, msg)
+}
+
+func (g *Gateway) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.DeleteAccountMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.UserId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+// handleExportUserData serves the engine's archive as a JSON file download.
+func (g *Gateway) handleExportUserData(w http.ResponseWriter, r *http.Request) {
+	result, ok := g.request(w, &pb.ExportUserDataMessage{UserId: r.PathValue("id"), Token: bearerToken(r)})
+	if !ok {
+		return
+	}
+	export, isExport := result.(*pb.UserDataExport)
+	if !isExport {
+		writeError(w, http.StatusBadGateway, "unexpected engine response")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.UserId + ".json"}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Archive)
+}
+
+func (g *Gateway) handleCreateSubreddit(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.SubredditMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	if msg.Id == "" {
+		msg.Id = utils.GenerateID()
+	}
+	g.create(w, msg.Id, msg)
+}
+
+func (g *Gateway) handleListSubreddits(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.ListSubredditsMessage{
+		Sort:   r.URL.Query().Get("sort"),
+		Limit:  limit,
+		After:  r.URL.Query().Get("after"),
+		Before: r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleGetTrendingSubreddits(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetTrendingSubredditsMessage{
+		Window: r.URL.Query().Get("window"),
+		Limit:  limit,
+	})
+}
+
+func (g *Gateway) handleGetSubreddit(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.GetSubredditMessage{Id: r.PathValue("id")})
+}
+
+func (g *Gateway) handleGetSubredditByName(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.GetSubredditMessage{Name: r.PathValue("name")})
+}
+
+func (g *Gateway) handleJoinSubreddit(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.JoinSubredditMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.SubredditId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleLeaveSubreddit(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.LeaveSubredditMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.SubredditId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleGetSubredditMembers(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetSubredditMembersMessage{
+		SubredditId: r.PathValue("id"),
+		Limit:       limit,
+		After:       r.URL.Query().Get("after"),
+		Before:      r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.GetSubscriptionsMessage{UserId: r.PathValue("id")})
+}
+
+func (g *Gateway) handleCreatePost(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.PostMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.SubredditId = r.PathValue("id")
+	if msg.Id == "" {
+		msg.Id = utils.GenerateID()
+	}
+	g.create(w, msg.Id, msg)
+}
+
+func (g *Gateway) handleGetSubredditPosts(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetFeedMessage{
+		SubredditIds: []string{r.PathValue("id")},
+		Limit:        limit,
+		Sort:         r.URL.Query().Get("sort"),
+		Window:       r.URL.Query().Get("window"),
+		After:        r.URL.Query().Get("after"),
+		Before:       r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleAddModerator(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.AddModeratorMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.SubredditId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+// handleBanUser bans the user_id in the body, or lifts the ban with
+// "unban": true.
+func (g *Gateway) handleBanUser(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.BanUserMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.SubredditId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleGetModLog(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetModLogMessage{
+		SubredditId: r.PathValue("id"),
+		Limit:       limit,
+		After:       r.URL.Query().Get("after"),
+		Before:      r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleCreateComment(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.CommentMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.PostId = r.PathValue("id")
+	if msg.Id == "" {
+		msg.Id = utils.GenerateID()
+	}
+	g.create(w, msg.Id, msg)
+}
+
+func (g *Gateway) handleGetComments(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.GetCommentsMessage{
+		PostId:   r.PathValue("id"),
+		Sort:     r.URL.Query().Get("sort"),
+		ParentId: r.URL.Query().Get("parent_id"),
+		After:    r.URL.Query().Get("after"),
+		Before:   r.URL.Query().Get("before"),
+		// Optional; routes the request straight to the subreddit's grain.
+		SubredditId: r.URL.Query().Get("subreddit_id"),
+	}
+	var ok bool
+	if msg.MaxDepth, ok = queryInt(w, r, "max_depth"); !ok {
+		return
+	}
+	if msg.Limit, ok = queryInt(w, r, "limit"); !ok {
+		return
+	}
+	if msg.ReplyLimit, ok = queryInt(w, r, "reply_limit"); !ok {
+		return
+	}
+	g.read(w, msg)
+}
+
+// handleRemoveContent serves both post and comment removal; the engine tells
+// them apart by ID.
+func (g *Gateway) handleRemoveContent(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.RemoveContentMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.TargetId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+// handleLockPost locks a post against new comments, or unlocks it with
+// "unlock": true.
+func (g *Gateway) handleLockPost(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.LockPostMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.PostId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleEditPost(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.EditPostMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.PostId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleDeletePost(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.DeletePostMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.PostId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleEditComment(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.EditCommentMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.CommentId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.DeleteCommentMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.CommentId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+// handleGetEditHistory serves the history of both posts and comments; the
+// engine tells them apart by ID.
+func (g *Gateway) handleGetEditHistory(w http.ResponseWriter, r *http.Request) {
+	g.read(w, &pb.GetEditHistoryMessage{
+		TargetId:    r.PathValue("id"),
+		SubredditId: r.URL.Query().Get("subreddit_id"),
+	})
+}
+
+func (g *Gateway) handleVote(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.VoteMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleGetFeed(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetFeedMessage{
+		SubredditIds: r.URL.Query()["subreddit_id"],
+		Limit:        limit,
+		Sort:         r.URL.Query().Get("sort"),
+		Window:       r.URL.Query().Get("window"),
+		After:        r.URL.Query().Get("after"),
+		Before:       r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleGetHomeFeed(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetHomeFeedMessage{
+		UserId: r.PathValue("id"),
+		Limit:  limit,
+		Sort:   r.URL.Query().Get("sort"),
+		Window: r.URL.Query().Get("window"),
+		After:  r.URL.Query().Get("after"),
+		Before: r.URL.Query().Get("before"),
+	})
+}
+
+// handleGetAllFeed serves both /api/feed/all and /api/feed/popular.
+func (g *Gateway) handleGetAllFeed(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetAllFeedMessage{
+		Popular: strings.HasSuffix(r.URL.Path, "/popular"),
+		Limit:   limit,
+		Sort:    r.URL.Query().Get("sort"),
+		Window:  r.URL.Query().Get("window"),
+		After:   r.URL.Query().Get("after"),
+		Before:  r.URL.Query().Get("before"),
+	})
+}
+
+func (g *Gateway) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.DirectMessageMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	if msg.Id == "" {
+		msg.Id = utils.GenerateID()
+	}
+	g.create(w, msg.Id, msg)
+}
+
+func (g *Gateway) handleGetMessages(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetDirectMessagesMessage{
+		UserId: r.PathValue("id"),
+		WithId: r.URL.Query().Get("with"),
+		Limit:  limit,
+		After:  r.URL.Query().Get("after"),
+		Before: r.URL.Query().Get("before"),
+		Token:  bearerToken(r),
+	})
+}
+
+func (g *Gateway) handleMarkRead(w http.ResponseWriter, r *http.Request) {
+	msg := &pb.MarkReadMessage{}
+	if !decodeRequest(w, r, msg) {
+		return
+	}
+	msg.Token = bearerToken(r)
+	msg.UserId = r.PathValue("id")
+	g.write(w, msg)
+}
+
+func (g *Gateway) handleGetConversations(w http.ResponseWriter, r *http.Request) {
+	limit, ok := queryInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	g.read(w, &pb.GetConversationsMessage{
+		UserId: r.PathValue("id"),
+		Limit:  limit,
+		After:  r.URL.Query().Get("after"),
+		Before: r.URL.Query().Get("before"),
+		Token:  bearerToken(r),
+	})
+}
+
+func (g *Gateway) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	msg := &pb.SearchMessage{
+		Query:       query.Get("q"),
+		Kinds:       query["kind"],
+		SubredditId: query.Get("subreddit_id"),
+		AuthorId:    query.Get("author_id"),
+		After:       query.Get("after"),
+		Before:      query.Get("before"),
+	}
+	var ok bool
+	if msg.Limit, ok = queryInt(w, r, "limit"); !ok {
+		return
+	}
+	if msg.CreatedAfter, ok = queryInt64(w, r, "created_after"); !ok {
+		return
+	}
+	if msg.CreatedBefore, ok = queryInt64(w, r, "created_before"); !ok {
+		return
+	}
+	g.read(w, msg)
+}
+
+// create forwards a request that creates a resource and answers 201 with its
+// ID.
+func (g *Gateway) create(w http.ResponseWriter, id string, msg proto.Message) {
+	if resp, ok := g.succeed(w, msg); ok {
+		writeJSON(w, http.StatusCreated, createdResponse{ID: id, Message: resp.Message})
+	}
+}
+
+// write forwards any other mutating request and answers 200 with the
+// engine's message.
+func (g *Gateway) write(w http.ResponseWriter, msg proto.Message) {
+	if resp, ok := g.succeed(w, msg); ok {
+		writeJSON(w, http.StatusOK, createdResponse{Message: resp.Message})
+	}
+}
+
+// succeed forwards a mutating request and returns the engine's success
+// response. Otherwise it has already answered the client.
+func (g *Gateway) succeed(w http.ResponseWriter, msg proto.Message) (*pb.SuccessResponse, bool) {
+	result, ok := g.request(w, msg)
+	if !ok {
+		return nil, false
+	}
+	resp, isSuccess := result.(*pb.SuccessResponse)
+	if !isSuccess {
+		writeError(w, http.StatusBadGateway, "unexpected engine response")
+		return nil, false
+	}
+	return resp, true
+}
+
+// read forwards a query and answers 200 with the engine's response message.
+func (g *Gateway) read(w http.ResponseWriter, msg proto.Message) {
+	result, ok := g.request(w, msg)
+	if !ok {
+		return
+	}
+	resp, isProto := result.(proto.Message)
+	if !isProto {
+		writeError(w, http.StatusBadGateway, "unexpected engine response")
+		return
+	}
+	writeProto(w, http.StatusOK, resp)
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	if err := decodeBody(r, msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// bearerToken extracts the session token from an "Authorization: Bearer"
+// header. Authenticated requests are checked by the engine, not here.
+func bearerToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token
+}
+
+func queryInt(w http.ResponseWriter, r *http.Request, key string) (int32, bool) {
+	value, ok := parseQueryInt(w, r, key, 32)
+	return int32(value), ok
+}
+
+func queryInt64(w http.ResponseWriter, r *http.Request, key string) (int64, bool) {
+	return parseQueryInt(w, r, key, 64)
+}
+
+// parseQueryInt reads an optional non-negative integer parameter of bitSize
+// bits. Invalid values are answered with 400.
+func parseQueryInt(w http.ResponseWriter, r *http.Request, key string, bitSize int) (int64, bool) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return 0, true
+	}
+	value, err := strconv.ParseInt(raw, 10, bitSize)
+	if err != nil || value < 0 {
+		writeError(w, http.StatusBadRequest, "invalid "+key+" parameter")
+		return 0, false
+	}
+	return value, true
+}
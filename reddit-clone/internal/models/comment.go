@@ -6,6 +6,13 @@ type Comment struct {
 	ParentID string // empty if top-level comment
 	AuthorID string
 	Content  string
+	Karma    int32 // Ups - Downs
+	Ups      int32
+	Downs    int32
 	Created  int64
-	Children []string // IDs of child comments
+	Children []string   // IDs of child comments
+	Removed  bool       // by a moderator; shown without content or author
+	Deleted  bool       // by its author; shown as "[deleted]"
+	Edited   int64      // when its author last edited it; zero if never
+	History  []Revision // earlier versions, oldest first
 }
@@ -7,4 +7,5 @@ type DirectMessage struct {
 	Content   string
 	Timestamp int64
 	ReplyToID string
+	Read      bool // the recipient has marked it read
 }
@@ -0,0 +1,23 @@
+package models
+
+// Actions recorded in a subreddit's moderation log.
+const (
+	ModAddModerator  = "add_moderator"
+	ModBan           = "ban"
+	ModUnban         = "unban"
+	ModRemovePost    = "remove_post"
+	ModRemoveComment = "remove_comment"
+	ModLock          = "lock"
+	ModUnlock        = "unlock"
+)
+
+// ModAction is an entry of a subreddit's append-only moderation log.
+type ModAction struct {
+	ID          string
+	SubredditID string
+	ModeratorID string
+	Action      string
+	TargetID    string // user, post or comment
+	Reason      string
+	Created     int64
+}
@@ -6,7 +6,14 @@ type Post struct {
 	AuthorID    string
 	Title       string
 	Content     string
-	Karma       int32
+	Karma       int32 // Ups - Downs
+	Ups         int32
+	Downs       int32
 	Created     int64
 	Votes       map[string]bool // user_id -> upvote(true)/downvote(false)
+	Removed     bool            // by a moderator; hidden from feeds
+	Locked      bool            // by a moderator; closed to new comments
+	Deleted     bool            // by its author; hidden from feeds
+	Edited      int64           // when its author last edited it; zero if never
+	History     []Revision      // earlier versions, oldest first
 }
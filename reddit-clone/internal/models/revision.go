@@ -0,0 +1,7 @@
+package models
+
+// Revision is an earlier version of a post or comment its author edited.
+type Revision struct {
+	Content string
+	Created int64 // when this version was written
+}
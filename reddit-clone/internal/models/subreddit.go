@@ -6,5 +6,7 @@ type Subreddit struct {
 	Description string
 	CreatorID   string
 	Members     map[string]bool // user_id -> membership status
+	Moderators  map[string]bool // user_id -> moderator; the creator is the first
+	Banned      map[string]bool // user_id -> banned from posting, commenting and voting
 	Created     int64
 }
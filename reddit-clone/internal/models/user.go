@@ -1,9 +1,25 @@
 package models
 
+// DeletedAuthor is credited with the posts and comments of deleted accounts.
+const DeletedAuthor = "[deleted]"
+
 type User struct {
-	ID       string
-	Username string
-	Password string
-	Karma    int32
-	Created  int64
+	ID           string
+	Username     string
+	Password     string // bcrypt hash
+	DisplayName  string
+	Bio          string
+	Karma        int32 // PostKarma + CommentKarma
+	PostKarma    int32
+	CommentKarma int32
+	Created      int64
+	// Deleted accounts keep their ID and karma; the rest of the profile and
+	// the password are cleared.
+	Deleted bool
+	// SessionsRevoked invalidates every session token issued before it (unix
+	// nanoseconds), e.g. after a password change.
+	SessionsRevoked int64
+	// LoggedOut holds the IDs of session tokens ended by logging out, with
+	// their expiry (unix seconds). They are dropped once expired.
+	LoggedOut map[string]int64
 }
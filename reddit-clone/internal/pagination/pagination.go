@@ -0,0 +1,178 @@
+// internal/pagination/pagination.go
+
+// Package pagination implements opaque cursor (keyset) pagination. A cursor
+// records the sort key of the item at the edge of a page, so the next page
+// starts after that item even if new items were inserted in the meantime.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrBothCursors   = errors.New("only one of after and before may be set")
+)
+
+// Key is the position of an item in an ordered list. Items compare by Score,
+// then Time, then ID; the ID makes every key unique.
+type Key struct {
+	Score float64 `json:"s,omitempty"`
+	Time  int64   `json:"t,omitempty"`
+	ID    string  `json:"i"`
+}
+
+// Order gives the direction of the Score and Time comparisons. IDs always
+// compare ascending.
+type Order struct {
+	ScoreDescending bool
+	TimeDescending  bool
+}
+
+// Compare returns -1 if a comes before b in the order, 1 if after and 0 if
+// the keys are equal.
+func (o Order) Compare(a, b Key) int {
+	if a.Score != b.Score {
+		return direction(a.Score < b.Score, o.ScoreDescending)
+	}
+	if a.Time != b.Time {
+		return direction(a.Time < b.Time, o.TimeDescending)
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+func direction(less, descending bool) int {
+	if less != descending {
+		return -1
+	}
+	return 1
+}
+
+// cursor is the encoded form of a page boundary. Now pins the clock for
+// orders that depend on the current time, such as ranked feeds, so every
+// page of a listing is ranked as of the first one.
+type cursor struct {
+	Key
+	Now int64 `json:"n,omitempty"`
+}
+
+func encode(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decode(token string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &cursor{}
+	if err := json.Unmarshal(raw, c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Request is a decoded page request.
+type Request struct {
+	limit  int
+	after  *cursor
+	before *cursor
+}
+
+// NewRequest decodes the after and before tokens of a request. A limit of
+// zero or less means no limit.
+func NewRequest(limit int32, after, before string) (Request, error) {
+	if after != "" && before != "" {
+		return Request{}, ErrBothCursors
+	}
+	req := Request{limit: int(limit)}
+	var err error
+	if after != "" {
+		req.after, err = decode(after)
+	}
+	if before != "" {
+		req.before, err = decode(before)
+	}
+	return req, err
+}
+
+// Now returns the clock pinned by the request's cursor, or fallback for a
+// first page.
+func (r Request) Now(fallback time.Time) time.Time {
+	for _, c := range []*cursor{r.after, r.before} {
+		if c != nil && c.Now != 0 {
+			return time.Unix(0, c.Now)
+		}
+	}
+	return fallback
+}
+
+// Page is one page of a listing. Next and Prev are empty at either end.
+type Page[T any] struct {
+	Items []T
+	Next  string
+	Prev  string
+	// Remaining counts the items after this page.
+	Remaining int
+}
+
+// Paginate cuts the page described by req out of items, which must already be
+// sorted by order. A non-zero now is recorded in the returned cursors; see
+// Request.Now.
+func Paginate[T any](items []T, key func(T) Key, order Order, req Request, now time.Time) Page[T] {
+	start, end := 0, len(items)
+	switch {
+	case req.after != nil:
+		start = search(items, key, order, req.after.Key)
+		if req.limit > 0 && end-start > req.limit {
+			end = start + req.limit
+		}
+	case req.before != nil:
+		end = search(items, key, order, req.before.Key)
+		if end > 0 && order.Compare(key(items[end-1]), req.before.Key) == 0 {
+			end--
+		}
+		if req.limit > 0 && end-start > req.limit {
+			start = end - req.limit
+		}
+	default:
+		if req.limit > 0 && end > req.limit {
+			end = req.limit
+		}
+	}
+
+	page := Page[T]{Items: items[start:end], Remaining: len(items) - end}
+	if len(page.Items) == 0 {
+		return page
+	}
+	var pinned int64
+	if !now.IsZero() {
+		pinned = now.UnixNano()
+	}
+	if start > 0 {
+		page.Prev = encode(cursor{Key: key(items[start]), Now: pinned})
+	}
+	if end < len(items) {
+		page.Next = encode(cursor{Key: key(items[end-1]), Now: pinned})
+	}
+	return page
+}
+
+// search returns the index of the first item ordered after k.
+func search[T any](items []T, key func(T) Key, order Order, k Key) int {
+	lo, hi := 0, len(items)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if order.Compare(key(items[mid]), k) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
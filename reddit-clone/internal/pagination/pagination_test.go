@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type item struct {
+	id      string
+	created int64
+}
+
+func itemKey(it item) Key {
+	return Key{Time: it.created, ID: it.id}
+}
+
+// newestFirst lists items by creation time, newest first.
+var newestFirst = Order{TimeDescending: true}
+
+func items(n int) []item {
+	list := make([]item, n)
+	for i := range list {
+		list[i] = item{id: fmt.Sprintf("i%02d", i), created: int64(n - i)}
+	}
+	return list
+}
+
+func ids(page Page[item]) string {
+	var out []string
+	for _, it := range page.Items {
+		out = append(out, it.id)
+	}
+	return fmt.Sprint(out)
+}
+
+func mustRequest(t *testing.T, limit int32, after, before string) Request {
+	t.Helper()
+	req, err := NewRequest(limit, after, before)
+	if err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+	return req
+}
+
+func TestPaginateForwardAndBack(t *testing.T) {
+	list := items(5)
+
+	first := Paginate(list, itemKey, newestFirst, mustRequest(t, 2, "", ""), time.Time{})
+	if ids(first) != "[i00 i01]" || first.Prev != "" || first.Next == "" || first.Remaining != 3 {
+		t.Fatalf("Unexpected first page %s (prev %q, remaining %d)", ids(first), first.Prev, first.Remaining)
+	}
+
+	second := Paginate(list, itemKey, newestFirst, mustRequest(t, 2, first.Next, ""), time.Time{})
+	if ids(second) != "[i02 i03]" || second.Prev == "" || second.Next == "" {
+		t.Fatalf("Unexpected second page %s", ids(second))
+	}
+
+	last := Paginate(list, itemKey, newestFirst, mustRequest(t, 2, second.Next, ""), time.Time{})
+	if ids(last) != "[i04]" || last.Next != "" || last.Remaining != 0 {
+		t.Fatalf("Unexpected last page %s (next %q)", ids(last), last.Next)
+	}
+
+	back := Paginate(list, itemKey, newestFirst, mustRequest(t, 2, "", last.Prev), time.Time{})
+	if ids(back) != "[i02 i03]" {
+		t.Errorf("Expected paging back to return the second page, got %s", ids(back))
+	}
+}
+
+func TestPaginateIsStableUnderInserts(t *testing.T) {
+	list := items(4)
+	first := Paginate(list, itemKey, newestFirst, mustRequest(t, 2, "", ""), time.Time{})
+
+	// New items arrive at the top of the listing between page requests.
+	grown := append([]item{{id: "new1", created: 100}, {id: "new2", created: 99}}, list...)
+	second := Paginate(grown, itemKey, newestFirst, mustRequest(t, 2, first.Next, ""), time.Time{})
+	if ids(second) != "[i02 i03]" {
+		t.Errorf("Expected the second page to continue after i01, got %s", ids(second))
+	}
+}
+
+func TestRequestPinsClock(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := Paginate(items(3), itemKey, newestFirst, mustRequest(t, 1, "", ""), now)
+
+	req := mustRequest(t, 1, first.Next, "")
+	if got := req.Now(now.Add(time.Hour)); !got.Equal(now) {
+		t.Errorf("Expected the cursor to pin %v, got %v", now, got)
+	}
+	if got := mustRequest(t, 1, "", "").Now(now); !got.Equal(now) {
+		t.Errorf("Expected a first page to use the fallback clock, got %v", got)
+	}
+}
+
+func TestNewRequestRejectsBadCursors(t *testing.T) {
+	if _, err := NewRequest(10, "garbage!", ""); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+	valid := Paginate(items(3), itemKey, newestFirst, mustRequest(t, 1, "", ""), time.Time{}).Next
+	if _, err := NewRequest(10, valid, valid); err != ErrBothCursors {
+		t.Errorf("Expected ErrBothCursors, got %v", err)
+	}
+}
+
+func TestOrderCompare(t *testing.T) {
+	ranked := Order{ScoreDescending: true, TimeDescending: true}
+	a := Key{Score: 5, Time: 1, ID: "a"}
+	b := Key{Score: 5, Time: 2, ID: "b"}
+	c := Key{Score: 9, Time: 0, ID: "c"}
+	if ranked.Compare(c, a) != -1 || ranked.Compare(b, a) != -1 || ranked.Compare(a, a) != 0 {
+		t.Error("Expected higher scores and then newer times to come first")
+	}
+	if (Order{}).Compare(a, b) != -1 {
+		t.Error("Expected ascending order by default")
+	}
+}
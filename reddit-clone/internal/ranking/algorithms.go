@@ -0,0 +1,84 @@
+// internal/ranking/algorithms.go
+package ranking
+
+import (
+	"math"
+	"time"
+
+	"reddit-clone/internal/models"
+)
+
+const (
+	// hotEpoch and hotDecay are the constants of Reddit's hot ranking: a post
+	// needs ten times the score to keep its place 12.5 hours later.
+	hotEpoch = 1134028003
+	hotDecay = 45000
+
+	// risingWindow is how recent a post must be to count as rising.
+	risingWindow = 24 * time.Hour
+)
+
+// hotScore is Reddit's hot ranking: the order of magnitude of the score plus
+// a term that grows linearly with the creation time.
+func hotScore(post *models.Post, _ time.Time) float64 {
+	score := float64(post.Karma)
+	order := math.Log10(math.Max(math.Abs(score), 1))
+
+	sign := 0.0
+	if score > 0 {
+		sign = 1
+	} else if score < 0 {
+		sign = -1
+	}
+
+	return sign*order + float64(post.Created-hotEpoch)/hotDecay
+}
+
+func newScore(post *models.Post, _ time.Time) float64 {
+	return float64(post.Created)
+}
+
+func topScore(post *models.Post, _ time.Time) float64 {
+	return float64(post.Karma)
+}
+
+func inWindow(post *models.Post, opts Options) bool {
+	return opts.Window == 0 || age(post, opts.Now) <= opts.Window
+}
+
+// risingScore is the score gained per hour, damped so that posts a few
+// minutes old cannot win on a single vote.
+func risingScore(post *models.Post, now time.Time) float64 {
+	return float64(post.Karma) / math.Pow(age(post, now).Hours()+2, 1.5)
+}
+
+func isRisingCandidate(post *models.Post, opts Options) bool {
+	return post.Karma > 0 && age(post, opts.Now) <= risingWindow
+}
+
+func controversialScore(post *models.Post, _ time.Time) float64 {
+	return Controversy(post.Ups, post.Downs)
+}
+
+// Controversy is Reddit's controversy measure: high for items with many votes
+// that are evenly split, and zero for items with only ups or only downs.
+func Controversy(ups, downs int32) float64 {
+	if ups <= 0 || downs <= 0 {
+		return 0
+	}
+	magnitude := float64(ups + downs)
+	balance := float64(downs) / float64(ups)
+	if ups < downs {
+		balance = float64(ups) / float64(downs)
+	}
+	return math.Pow(magnitude, balance)
+}
+
+// age is how long ago the post was created, never negative.
+func age(post *models.Post, now time.Time) time.Duration {
+	created := time.Unix(post.Created, 0)
+	if created.After(now) {
+		return 0
+	}
+	return now.Sub(created)
+}
@@ -0,0 +1,140 @@
+// internal/ranking/ranking.go
+
+// Package ranking orders posts for feeds. Algorithms are registered by name
+// so that GetFeedMessage.Sort can select one and new ones can be plugged in
+// without touching the engine.
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"reddit-clone/internal/models"
+)
+
+// Names of the built-in algorithms.
+const (
+	Hot           = "hot"
+	New           = "new"
+	Top           = "top"
+	Rising        = "rising"
+	Controversial = "controversial"
+
+	// Default is used when a request does not name an algorithm.
+	Default = Hot
+)
+
+var (
+	ErrUnknownSort   = errors.New("unknown feed sort")
+	ErrUnknownWindow = errors.New("unknown time window")
+)
+
+// Clock returns the current time. Rankings take it as a parameter so that
+// tests can pin the time.
+type Clock func() time.Time
+
+// Options are the per-request inputs of a ranking.
+type Options struct {
+	Now time.Time
+	// Window limits Top to posts created within it. Zero means all time.
+	Window time.Duration
+}
+
+// Algorithm scores posts; a higher score ranks first. Posts rejected by
+// Include are left out of the ranking. A nil Include keeps every post.
+type Algorithm struct {
+	Score   func(post *models.Post, now time.Time) float64
+	Include func(post *models.Post, opts Options) bool
+}
+
+var (
+	mu         sync.RWMutex
+	algorithms = map[string]Algorithm{
+		Hot:           {Score: hotScore},
+		New:           {Score: newScore},
+		Top:           {Score: topScore, Include: inWindow},
+		Rising:        {Score: risingScore, Include: isRisingCandidate},
+		Controversial: {Score: controversialScore},
+	}
+)
+
+// Register makes an algorithm available under name, replacing any existing
+// algorithm of that name.
+func Register(name string, algorithm Algorithm) {
+	mu.Lock()
+	defer mu.Unlock()
+	algorithms[name] = algorithm
+}
+
+// Lookup returns the algorithm registered under name; "" selects Default.
+func Lookup(name string) (Algorithm, error) {
+	if name == "" {
+		name = Default
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	algorithm, exists := algorithms[name]
+	if !exists {
+		return Algorithm{}, fmt.Errorf("%w %q", ErrUnknownSort, name)
+	}
+	return algorithm, nil
+}
+
+// Scored is a ranked post together with the score it was ranked by.
+type Scored struct {
+	Post  *models.Post
+	Score float64
+}
+
+// Rank returns the posts selected by the named algorithm, best first. Equal
+// scores are ordered newest first and then by ID so that results are stable.
+// The input slice is not modified.
+func Rank(posts []*models.Post, name string, opts Options) ([]Scored, error) {
+	algorithm, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]Scored, 0, len(posts))
+	for _, post := range posts {
+		if algorithm.Include != nil && !algorithm.Include(post, opts) {
+			continue
+		}
+		ranked = append(ranked, Scored{Post: post, Score: algorithm.Score(post, opts.Now)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Post.Created != b.Post.Created {
+			return a.Post.Created > b.Post.Created
+		}
+		return a.Post.ID < b.Post.ID
+	})
+	return ranked, nil
+}
+
+// ParseWindow converts a Top time window name into a duration.
+func ParseWindow(name string) (time.Duration, error) {
+	switch name {
+	case "", "all":
+		return 0, nil
+	case "hour":
+		return time.Hour, nil
+	case "day":
+		return 24 * time.Hour, nil
+	case "week":
+		return 7 * 24 * time.Hour, nil
+	case "month":
+		return 30 * 24 * time.Hour, nil
+	case "year":
+		return 365 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnknownWindow, name)
+	}
+}
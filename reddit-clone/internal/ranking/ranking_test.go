@@ -0,0 +1,115 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"reddit-clone/internal/models"
+)
+
+var testNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func hoursAgo(h float64) int64 {
+	return testNow.Add(-time.Duration(h * float64(time.Hour))).Unix()
+}
+
+func testPosts() []*models.Post {
+	return []*models.Post{
+		{ID: "old-popular", Karma: 1000, Ups: 1000, Created: hoursAgo(72)},
+		{ID: "fresh", Karma: 0, Created: hoursAgo(0)},
+		{ID: "recent-good", Karma: 50, Ups: 60, Downs: 10, Created: hoursAgo(2)},
+		{ID: "divisive", Karma: 0, Ups: 40, Downs: 40, Created: hoursAgo(10)},
+		{ID: "disliked", Karma: -20, Ups: 5, Downs: 25, Created: hoursAgo(5)},
+	}
+}
+
+func rankIDs(t *testing.T, name string, opts Options) []string {
+	t.Helper()
+	ranked, err := Rank(testPosts(), name, opts)
+	if err != nil {
+		t.Fatalf("Failed to rank by %s: %v", name, err)
+	}
+	ids := make([]string, len(ranked))
+	for i, entry := range ranked {
+		ids[i] = entry.Post.ID
+	}
+	return ids
+}
+
+func TestRankAlgorithms(t *testing.T) {
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   []string
+	}{
+		{Hot, 0, []string{"recent-good", "fresh", "divisive", "disliked", "old-popular"}},
+		{New, 0, []string{"fresh", "recent-good", "disliked", "divisive", "old-popular"}},
+		{Top, 0, []string{"old-popular", "recent-good", "fresh", "divisive", "disliked"}},
+		{Top, 24 * time.Hour, []string{"recent-good", "fresh", "divisive", "disliked"}},
+		{Rising, 0, []string{"recent-good"}},
+		{Controversial, 0, []string{"divisive", "recent-good", "disliked", "fresh", "old-popular"}},
+		{"", 0, []string{"recent-good", "fresh", "divisive", "disliked", "old-popular"}},
+	}
+	for _, tt := range tests {
+		got := rankIDs(t, tt.name, Options{Now: testNow, Window: tt.window})
+		if len(got) != len(tt.want) {
+			t.Errorf("%s (window %v): expected %v, got %v", tt.name, tt.window, tt.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s (window %v): expected %v, got %v", tt.name, tt.window, tt.want, got)
+				break
+			}
+		}
+	}
+}
+
+func TestHotScoreDecaysWithAge(t *testing.T) {
+	// Ten times the score buys exactly hotDecay seconds of age.
+	newer := &models.Post{Karma: 10, Created: hoursAgo(0)}
+	older := &models.Post{Karma: 100, Created: newer.Created - hotDecay}
+	if diff := hotScore(newer, testNow) - hotScore(older, testNow); math.Abs(diff) > 1e-9 {
+		t.Errorf("Expected equal hot scores, got a difference of %v", diff)
+	}
+
+	// A brand new post must not divide by zero.
+	if score := risingScore(&models.Post{Karma: 1, Created: testNow.Unix()}, testNow); math.IsInf(score, 0) || math.IsNaN(score) {
+		t.Errorf("Expected a finite rising score for a new post, got %v", score)
+	}
+}
+
+func TestRankDoesNotDependOnWallClock(t *testing.T) {
+	first := rankIDs(t, Rising, Options{Now: testNow})
+	later := rankIDs(t, Rising, Options{Now: testNow.Add(48 * time.Hour)})
+	if len(first) != 1 || len(later) != 0 {
+		t.Errorf("Expected rising to depend only on the given time, got %v then %v", first, later)
+	}
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	if _, err := Lookup("nonsense"); err == nil {
+		t.Error("Expected an error for an unknown algorithm")
+	}
+
+	Register("by-id", Algorithm{Score: func(post *models.Post, _ time.Time) float64 {
+		return -float64(len(post.ID))
+	}})
+	got := rankIDs(t, "by-id", Options{Now: testNow})
+	if got[0] != "fresh" {
+		t.Errorf("Expected the registered algorithm to rank the shortest ID first, got %v", got)
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	if d, err := ParseWindow("week"); err != nil || d != 7*24*time.Hour {
+		t.Errorf("Expected a week, got %v (%v)", d, err)
+	}
+	if d, err := ParseWindow(""); err != nil || d != 0 {
+		t.Errorf("Expected all time, got %v (%v)", d, err)
+	}
+	if _, err := ParseWindow("decade"); err == nil {
+		t.Error("Expected an error for an unknown window")
+	}
+}
@@ -0,0 +1,358 @@
+// internal/search/index.go
+
+// Package search is the engine's full-text search: an in-memory inverted
+// index over posts, comments and subreddits, ranked with BM25. The index is
+// built from the store when the engine starts and kept up to date from the
+// engine's domain events.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/pagination"
+	"reddit-clone/internal/store"
+)
+
+// Kinds of Document.
+const (
+	KindPost      = "post"
+	KindComment   = "comment"
+	KindSubreddit = "subreddit"
+)
+
+// BM25 parameters: k1 limits how much repeating a term raises a score, b how
+// strongly long documents are penalised.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Order is the order of Search results, best first, and Result.Key is their
+// position in it.
+var Order = pagination.Order{ScoreDescending: true, TimeDescending: true}
+
+var (
+	ErrEmptyQuery  = errors.New("search query is required")
+	ErrUnknownKind = errors.New("unknown search kind")
+)
+
+// Document is a searchable item. Title and Content are indexed.
+type Document struct {
+	Kind        string
+	ID          string
+	SubredditID string // a subreddit's own ID
+	PostID      string // comments only
+	AuthorID    string // a subreddit's creator
+	Title       string // post title or subreddit name
+	Content     string
+	Created     int64
+}
+
+// Query selects and ranks documents. Empty filters match everything.
+type Query struct {
+	Text        string
+	Kinds       []string
+	SubredditID string
+	AuthorID    string
+	// CreatedAfter (inclusive) and CreatedBefore (exclusive) bound
+	// Document.Created in unix seconds; zero leaves a side open.
+	CreatedAfter  int64
+	CreatedBefore int64
+}
+
+type Result struct {
+	Document
+	Score float64
+}
+
+func (r Result) Key() pagination.Key {
+	return pagination.Key{Score: r.Score, Time: r.Created, ID: r.Kind + ":" + r.ID}
+}
+
+type docKey struct {
+	kind, id string
+}
+
+type entry struct {
+	doc   Document
+	terms map[string]int // term -> frequency
+	size  int            // number of terms
+}
+
+// Index is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[docKey]*entry
+	postings map[string]map[docKey]int // term -> document -> frequency
+	size     int                       // sum of every document's size
+}
+
+var _ events.Sink = (*Index)(nil)
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[docKey]*entry),
+		postings: make(map[string]map[docKey]int),
+	}
+}
+
+// Add indexes doc, replacing any document of the same kind and ID.
+func (i *Index) Add(doc Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	key := docKey{doc.Kind, doc.ID}
+	i.remove(key)
+
+	e := &entry{doc: doc, terms: make(map[string]int)}
+	for _, term := range Tokenize(doc.Title + " " + doc.Content) {
+		e.terms[term]++
+		e.size++
+	}
+	for term, freq := range e.terms {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[docKey]int)
+		}
+		i.postings[term][key] = freq
+	}
+	i.docs[key] = e
+	i.size += e.size
+}
+
+// Remove drops a document from the index. Unknown documents are ignored.
+func (i *Index) Remove(kind, id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(docKey{kind, id})
+}
+
+func (i *Index) remove(key docKey) {
+	e, exists := i.docs[key]
+	if !exists {
+		return
+	}
+	for term := range e.terms {
+		delete(i.postings[term], key)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docs, key)
+	i.size -= e.size
+}
+
+// Len returns the number of indexed documents.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+// Search returns the documents matching any term of q.Text and every filter
+// of q, in Order.
+func (i *Index) Search(q Query) ([]Result, error) {
+	terms := Tokenize(q.Text)
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	kinds := make(map[string]bool, len(q.Kinds))
+	for _, kind := range q.Kinds {
+		if kind != KindPost && kind != KindComment && kind != KindSubreddit {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+		}
+		kinds[kind] = true
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(i.docs) == 0 {
+		return []Result{}, nil
+	}
+	n := float64(len(i.docs))
+	averageSize := float64(i.size) / n
+
+	scores := make(map[docKey]float64)
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := i.postings[term]
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for key, freq := range postings {
+			e := i.docs[key]
+			if !q.matches(e.doc, kinds) {
+				continue
+			}
+			tf := float64(freq)
+			scores[key] += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*float64(e.size)/averageSize))
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for key, score := range scores {
+		results = append(results, Result{Document: i.docs[key].doc, Score: score})
+	}
+	sort.Slice(results, func(a, b int) bool {
+		return Order.Compare(results[a].Key(), results[b].Key()) < 0
+	})
+	return results, nil
+}
+
+func (q Query) matches(doc Document, kinds map[string]bool) bool {
+	switch {
+	case len(kinds) > 0 && !kinds[doc.Kind]:
+		return false
+	case q.SubredditID != "" && doc.SubredditID != q.SubredditID:
+		return false
+	case q.AuthorID != "" && doc.AuthorID != q.AuthorID:
+		return false
+	case q.CreatedAfter != 0 && doc.Created < q.CreatedAfter:
+		return false
+	case q.CreatedBefore != 0 && doc.Created >= q.CreatedBefore:
+		return false
+	}
+	return true
+}
+
+// Handle indexes the posts, comments and subreddits of domain events,
+// reindexes edited content, drops content that is deleted by its author or
+// removed by a moderator and anonymizes the content of deleted accounts.
+// Attach the index to the engine's EventStream with events.Attach.
+func (i *Index) Handle(event events.Event) {
+	switch event := event.(type) {
+	case *events.PostCreated:
+		i.Add(postDocument(event.Post))
+	case *events.PostEdited:
+		i.Add(postDocument(event.Post))
+	case *events.CommentAdded:
+		i.Add(i.commentDocument(event.Comment, event.SubredditID))
+	case *events.CommentEdited:
+		i.Add(i.commentDocument(event.Comment, event.SubredditID))
+	case *events.SubredditCreated:
+		i.Add(subredditDocument(event.Subreddit))
+	case *events.AccountDeleted:
+		i.reassign(event.UserID, models.DeletedAuthor)
+	case *events.PostDeleted:
+		i.Remove(KindPost, event.PostID)
+	case *events.CommentDeleted:
+		i.Remove(KindComment, event.CommentID)
+	case *events.ModActionTaken:
+		switch event.Action.Action {
+		case models.ModRemovePost:
+			i.Remove(KindPost, event.Action.TargetID)
+		case models.ModRemoveComment:
+			i.Remove(KindComment, event.Action.TargetID)
+		}
+	}
+}
+
+// reassign credits the indexed posts and comments of one author to another.
+func (i *Index) reassign(from, to string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, e := range i.docs {
+		if e.doc.Kind != KindSubreddit && e.doc.AuthorID == from {
+			e.doc.AuthorID = to
+		}
+	}
+}
+
+// Rebuild replaces the documents of the index with the subreddits, posts and
+// comments in s, leaving out what Handle would have dropped.
+func (i *Index) Rebuild(s store.Store) error {
+	rebuilt := NewIndex()
+	subreddits, err := s.ListSubreddits()
+	if err != nil {
+		return err
+	}
+	for _, subreddit := range subreddits {
+		rebuilt.Add(subredditDocument(subreddit))
+		posts, err := s.GetSubredditPosts(subreddit.ID)
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			if !post.Deleted && !post.Removed {
+				rebuilt.Add(postDocument(post))
+			}
+			// Comments stay searchable when their post is taken down.
+			comments, err := s.GetComments(post.ID)
+			if err != nil {
+				return err
+			}
+			for _, comment := range comments {
+				if !comment.Deleted && !comment.Removed {
+					rebuilt.Add(rebuilt.commentDocument(comment, post.SubredditID))
+				}
+			}
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.docs, i.postings, i.size = rebuilt.docs, rebuilt.postings, rebuilt.size
+	return nil
+}
+
+func subredditDocument(subreddit *models.Subreddit) Document {
+	return Document{
+		Kind:        KindSubreddit,
+		ID:          subreddit.ID,
+		SubredditID: subreddit.ID,
+		AuthorID:    subreddit.CreatorID,
+		Title:       subreddit.Name,
+		Content:     subreddit.Description,
+		Created:     subreddit.Created,
+	}
+}
+
+func postDocument(post *models.Post) Document {
+	return Document{
+		Kind:        KindPost,
+		ID:          post.ID,
+		SubredditID: post.SubredditID,
+		AuthorID:    post.AuthorID,
+		Title:       post.Title,
+		Content:     post.Content,
+		Created:     post.Created,
+	}
+}
+
+// commentDocument indexes comment under subredditID or, if that is empty,
+// the subreddit of its indexed post.
+func (i *Index) commentDocument(comment *models.Comment, subredditID string) Document {
+	if subredditID == "" {
+		subredditID = i.postSubreddit(comment.PostID)
+	}
+	return Document{
+		Kind:        KindComment,
+		ID:          comment.ID,
+		SubredditID: subredditID,
+		PostID:      comment.PostID,
+		AuthorID:    comment.AuthorID,
+		Content:     comment.Content,
+		Created:     comment.Created,
+	}
+}
+
+// postSubreddit returns the subreddit of an indexed post, so that comments
+// can be filtered by subreddit without a store lookup.
+func (i *Index) postSubreddit(postID string) string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if e, exists := i.docs[docKey{KindPost, postID}]; exists {
+		return e.doc.SubredditID
+	}
+	return ""
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"testing"
+
+	"reddit-clone/internal/events"
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store/memory"
+)
+
+func ids(results []Result) []string {
+	out := make([]string, 0, len(results))
+	for _, result := range results {
+		out = append(out, result.ID)
+	}
+	return out
+}
+
+func TestSearchRanksByBM25(t *testing.T) {
+	index := NewIndex()
+	index.Add(Document{Kind: KindPost, ID: "long", Title: "gopher", Content: "a gopher story that goes on and on about many other animals and places"})
+	index.Add(Document{Kind: KindPost, ID: "short", Title: "gopher news"})
+	index.Add(Document{Kind: KindPost, ID: "twice", Title: "gopher", Content: "gophers everywhere"})
+	index.Add(Document{Kind: KindPost, ID: "other", Title: "rust news"})
+
+	results, err := index.Search(Query{Text: "gophers"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := ids(results); len(got) != 3 || got[0] != "twice" || got[2] != "long" {
+		t.Errorf("Expected twice, short, long, got %v", got)
+	}
+
+	// A rarer term weighs more than a common one.
+	results, _ = index.Search(Query{Text: "gopher news"})
+	if got := ids(results); got[0] != "short" {
+		t.Errorf("Expected the post matching both terms first, got %v", got)
+	}
+	results, _ = index.Search(Query{Text: "rust gopher"})
+	if got := ids(results); got[0] != "other" {
+		t.Errorf("Expected the only rust post first, got %v", got)
+	}
+
+	if _, err := index.Search(Query{Text: "the"}); err != ErrEmptyQuery {
+		t.Errorf("Expected ErrEmptyQuery for a query of stop words, got %v", err)
+	}
+	if _, err := index.Search(Query{Text: "gopher", Kinds: []string{"user"}}); err == nil {
+		t.Error("Expected an error for an unknown kind")
+	}
+}
+
+func TestSearchFilters(t *testing.T) {
+	index := NewIndex()
+	index.Add(Document{Kind: KindSubreddit, ID: "golang", SubredditID: "golang", AuthorID: "alice", Title: "golang", Created: 10})
+	index.Add(Document{Kind: KindPost, ID: "p1", SubredditID: "golang", AuthorID: "alice", Title: "golang tips", Created: 20})
+	index.Add(Document{Kind: KindComment, ID: "c1", SubredditID: "golang", PostID: "p1", AuthorID: "bob", Content: "golang rocks", Created: 30})
+	index.Add(Document{Kind: KindPost, ID: "p2", SubredditID: "rust", AuthorID: "bob", Title: "golang vs rust", Created: 40})
+
+	tests := []struct {
+		name  string
+		query Query
+		want  int
+	}{
+		{"all", Query{}, 4},
+		{"kinds", Query{Kinds: []string{KindPost, KindComment}}, 3},
+		{"subreddit", Query{SubredditID: "golang"}, 3},
+		{"author", Query{AuthorID: "bob"}, 2},
+		{"after", Query{CreatedAfter: 30}, 2},
+		{"before", Query{CreatedBefore: 30}, 2},
+		{"combined", Query{Kinds: []string{KindPost}, AuthorID: "bob", CreatedAfter: 35}, 1},
+	}
+	for _, tt := range tests {
+		tt.query.Text = "golang"
+		results, err := index.Search(tt.query)
+		if err != nil {
+			t.Fatalf("%s: Search failed: %v", tt.name, err)
+		}
+		if len(results) != tt.want {
+			t.Errorf("%s: expected %d results, got %v", tt.name, tt.want, ids(results))
+		}
+	}
+}
+
+func TestIndexFollowsEvents(t *testing.T) {
+	index := NewIndex()
+	index.Handle(&events.SubredditCreated{Subreddit: &models.Subreddit{ID: "s1", Name: "gardening", CreatorID: "alice"}})
+	index.Handle(&events.PostCreated{Post: &models.Post{ID: "p1", SubredditID: "s1", AuthorID: "alice", Title: "Tomatoes"}})
+	index.Handle(&events.CommentAdded{Comment: &models.Comment{ID: "c1", PostID: "p1", AuthorID: "bob", Content: "My tomato plants"}})
+	index.Handle(&events.VoteCast{TargetID: "p1", UserID: "bob", IsUpvote: true})
+
+	if index.Len() != 3 {
+		t.Fatalf("Expected 3 documents, got %d", index.Len())
+	}
+	results, _ := index.Search(Query{Text: "tomato", SubredditID: "s1"})
+	if got := ids(results); len(got) != 2 {
+		t.Errorf("Expected the post and its comment in s1, got %v", got)
+	}
+
+	// Re-adding replaces and removing forgets.
+	index.Add(Document{Kind: KindPost, ID: "p1", SubredditID: "s1", Title: "Cucumbers"})
+	index.Remove(KindComment, "c1")
+	results, _ = index.Search(Query{Text: "tomato"})
+	if len(results) != 0 {
+		t.Errorf("Expected no tomato results, got %v", ids(results))
+	}
+	if index.Len() != 2 {
+		t.Errorf("Expected 2 documents, got %d", index.Len())
+	}
+
+	// Edits reindex and deletions forget.
+	index.Handle(&events.PostEdited{Post: &models.Post{ID: "p1", SubredditID: "s1", Title: "Cucumbers", Content: "and peppers"}})
+	if results, _ = index.Search(Query{Text: "peppers"}); len(results) != 1 {
+		t.Errorf("Expected the edited post to match, got %v", ids(results))
+	}
+	index.Handle(&events.PostDeleted{PostID: "p1", SubredditID: "s1"})
+	if index.Len() != 1 {
+		t.Errorf("Expected 1 document after deleting p1, got %d", index.Len())
+	}
+
+	// A deleted account's posts stay searchable under [deleted].
+	index.Handle(&events.PostCreated{Post: &models.Post{ID: "p2", SubredditID: "s1", AuthorID: "alice", Title: "Gardening gloves"}})
+	index.Handle(&events.AccountDeleted{UserID: "alice"})
+	if results, _ = index.Search(Query{Text: "gardening", AuthorID: "alice"}); len(results) != 1 || results[0].Kind != KindSubreddit {
+		t.Errorf("Expected only alice's subreddit under alice's ID, got %v", ids(results))
+	}
+	if results, _ = index.Search(Query{Text: "gloves", AuthorID: models.DeletedAuthor}); len(results) != 1 {
+		t.Errorf("Expected p2 under %s, got %v", models.DeletedAuthor, ids(results))
+	}
+}
+
+func TestRebuildFromStore(t *testing.T) {
+	s := memory.NewMemoryStore()
+	for _, err := range []error{
+		s.CreateSubreddit(&models.Subreddit{ID: "s1", Name: "gardening", CreatorID: "alice", Members: map[string]bool{}}),
+		s.CreatePost(&models.Post{ID: "p1", SubredditID: "s1", AuthorID: "alice", Title: "Tomatoes"}),
+		s.CreatePost(&models.Post{ID: "p2", SubredditID: "s1", AuthorID: "alice", Title: "Tomato soup"}),
+		s.AddComment(&models.Comment{ID: "c1", PostID: "p2", AuthorID: "bob", Content: "My tomato plants"}),
+		s.AddComment(&models.Comment{ID: "c2", PostID: "p1", AuthorID: "bob", Content: "Tomato spam"}),
+		s.DeletePost("p2"),
+		s.RemoveComment("c2"),
+	} {
+		if err != nil {
+			t.Fatalf("Failed to fill the store: %v", err)
+		}
+	}
+
+	index := NewIndex()
+	index.Add(Document{Kind: KindPost, ID: "stale", Title: "tomato"})
+	if err := index.Rebuild(s); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if index.Len() != 3 {
+		t.Errorf("Expected the subreddit, p1 and c1, got %d documents", index.Len())
+	}
+	results, _ := index.Search(Query{Text: "tomato", SubredditID: "s1"})
+	if got := ids(results); len(got) != 2 {
+		t.Errorf("Expected p1 and the comment on the deleted p2 in s1, got %v", got)
+	}
+
+	// Comment events name their subreddit, so the post need not be indexed.
+	index.Handle(&events.CommentAdded{Comment: &models.Comment{ID: "c3", PostID: "p9", Content: "tomato"}, SubredditID: "s2"})
+	if results, _ = index.Search(Query{Text: "tomato", SubredditID: "s2"}); len(results) != 1 {
+		t.Errorf("Expected c3 in s2, got %v", ids(results))
+	}
+}
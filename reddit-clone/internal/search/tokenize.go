@@ -0,0 +1,157 @@
+// internal/search/tokenize.go
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopWords are too common to tell documents apart and are not indexed.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "if": true, "in": true,
+	"into": true, "is": true, "it": true, "no": true, "not": true, "of": true,
+	"on": true, "or": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// Tokenize splits text into index terms: lower-cased runs of letters and
+// digits, stemmed, without stop words. Queries and documents go through the
+// same function, so "Posting" finds "posts".
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, word := range words {
+		if !stopWords[word] {
+			terms = append(terms, Stem(word))
+		}
+	}
+	return terms
+}
+
+// Stem reduces an English word to its stem with steps 1 and 5a of the Porter
+// algorithm, which strip plurals, -ed/-ing endings and a final e ("ponies" ->
+// "poni", "hopping" -> "hop", "tomatoes" -> "tomato"). Stems need not be
+// words; they only have to match. Words with non-ASCII letters are returned
+// unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 || !isASCII(word) {
+		return word
+	}
+
+	// Step 1a: plurals.
+	switch {
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"):
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	// Step 1b: past tenses and gerunds.
+	if stem, ok := strings.CutSuffix(word, "eed"); ok {
+		if measure(stem) > 0 {
+			word = stem + "ee"
+		}
+	} else if stem, ok := cutSuffix(word, "ed", "ing"); ok && hasVowel(stem) {
+		word = stem
+		switch {
+		case strings.HasSuffix(word, "at"), strings.HasSuffix(word, "bl"), strings.HasSuffix(word, "iz"):
+			word += "e"
+		case doubleConsonant(word) && !strings.ContainsAny(word[len(word)-1:], "lsz"):
+			word = word[:len(word)-1]
+		case measure(word) == 1 && endsCVC(word):
+			word += "e"
+		}
+	}
+
+	// Step 1c: a final y after a vowel-bearing stem.
+	if stem, ok := strings.CutSuffix(word, "y"); ok && hasVowel(stem) {
+		word = stem + "i"
+	}
+
+	// Step 5a: a final e, unless the stem is short ("file").
+	if stem, ok := strings.CutSuffix(word, "e"); ok {
+		if m := measure(stem); m > 1 || m == 1 && !endsCVC(stem) {
+			word = stem
+		}
+	}
+	return word
+}
+
+func cutSuffix(word string, suffixes ...string) (string, bool) {
+	for _, suffix := range suffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok {
+			return stem, true
+		}
+	}
+	return word, false
+}
+
+func isASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// consonant follows Porter: y is a consonant at the start of a word or
+// after a vowel.
+func consonant(word string, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !consonant(word, i-1)
+	}
+	return true
+}
+
+func hasVowel(word string) bool {
+	for i := range word {
+		if !consonant(word, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// measure counts the vowel-consonant sequences of a stem, Porter's m.
+func measure(stem string) int {
+	m, i, n := 0, 0, len(stem)
+	for i < n && consonant(stem, i) {
+		i++
+	}
+	for i < n {
+		for i < n && !consonant(stem, i) {
+			i++
+		}
+		if i == n {
+			break
+		}
+		for i < n && consonant(stem, i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func doubleConsonant(word string) bool {
+	n := len(word)
+	return n >= 2 && word[n-1] == word[n-2] && consonant(word, n-1)
+}
+
+// endsCVC reports whether word ends consonant-vowel-consonant, the last not
+// being w, x or y, as in "hop" or "fil".
+func endsCVC(word string) bool {
+	n := len(word)
+	return n >= 3 && consonant(word, n-3) && !consonant(word, n-2) && consonant(word, n-1) &&
+		!strings.ContainsAny(word[n-1:], "wxy")
+}
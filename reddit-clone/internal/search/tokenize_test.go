@@ -0,0 +1,41 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"caresses": "caress",
+		"ponies":   "poni",
+		"pony":     "poni",
+		"cats":     "cat",
+		"posts":    "post",
+		"posted":   "post",
+		"posting":  "post",
+		"agreed":   "agre",
+		"feed":     "feed",
+		"hopping":  "hop",
+		"falling":  "fall",
+		"filing":   "file",
+		"troubled": "troubl",
+		"tomatoes": "tomato",
+		"sing":     "sing",
+		"go":       "go",
+		"café":     "café",
+	}
+	for word, want := range tests {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("The Gophers are POSTING: gopher-posts, 2 of them!")
+	want := []string{"gopher", "post", "gopher", "post", "2", "them"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %q, want %q", got, want)
+	}
+}
@@ -20,6 +20,7 @@ var mutex = &sync.Mutex{}
 type SimulationController struct {
 	system           *protoactor.ActorSystem
 	enginePID        *protoactor.PID
+	clientOpts       []actor.ClientOption
 	clients          []*protoactor.PID
 	zipf             *rand.Zipf
 	metrics          *metrics.RedditMetrics
@@ -30,7 +31,7 @@ type SimulationController struct {
 	baseCount        atomic.Int32
 }
 
-func NewSimulationController(system *protoactor.ActorSystem, enginePID *protoactor.PID, metrics *metrics.RedditMetrics) *SimulationController {
+func NewSimulationController(system *protoactor.ActorSystem, enginePID *protoactor.PID, metrics *metrics.RedditMetrics, clientOpts ...actor.ClientOption) *SimulationController {
 	source := rand.NewSource(time.Now().UnixNano())
 	r := rand.New(source)
 
@@ -49,6 +50,7 @@ func NewSimulationController(system *protoactor.ActorSystem, enginePID *protoact
 	return &SimulationController{
 		system:           system,
 		enginePID:        enginePID,
+		clientOpts:       clientOpts,
 		clients:          make([]*protoactor.PID, 0),
 		metrics:          metrics,
 		zipf:             rand.NewZipf(r, 1.1, 1.0, 1000),
@@ -84,6 +86,7 @@ func (s *SimulationController) Start(numClients int) error {
 			s.enginePID,
 			behavior,
 			s.metrics,
+			s.clientOpts...,
 		)
 
 		props := protoactor.PropsFromProducer(func() protoactor.Actor {
@@ -269,6 +272,7 @@ func (s *SimulationController) addUsers(count int) error {
 			s.enginePID,
 			behavior,
 			s.metrics,
+			s.clientOpts...,
 		)
 
 		props := protoactor.PropsFromProducer(func() protoactor.Actor {
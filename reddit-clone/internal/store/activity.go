@@ -0,0 +1,16 @@
+// store/activity.go
+package store
+
+import "reddit-clone/internal/models"
+
+// LivePost reports whether a post counts as activity of its subreddit: it
+// is neither removed by a moderator nor deleted by its author.
+func LivePost(post *models.Post) bool {
+	return !post.Removed && !post.Deleted
+}
+
+// LiveComment is LivePost for comments. Comments on a post that is not live
+// do not count either.
+func LiveComment(comment *models.Comment) bool {
+	return !comment.Removed && !comment.Deleted
+}
@@ -0,0 +1,350 @@
+// store/bolt/schema.go
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+var (
+	metaBucket           = []byte("meta")
+	usersBucket          = []byte("users")
+	subredditsBucket     = []byte("subreddits")
+	postsBucket          = []byte("posts")
+	commentsBucket       = []byte("comments")
+	inboxBucket          = []byte("inbox")
+	votesBucket          = []byte("votes")
+	subredditPostsBucket = []byte("subreddit_posts")
+	postCommentsBucket   = []byte("post_comments")
+	authorPostsBucket    = []byte("author_posts")
+	authorCommentsBucket = []byte("author_comments")
+	userSubredditsBucket = []byte("user_subreddits")
+	modLogBucket         = []byte("mod_log")
+	subredditNamesBucket = []byte("subreddit_names")
+	messageKeysBucket    = []byte("message_keys")
+	sentBucket           = []byte("sent")
+	userVotesBucket      = []byte("user_votes")
+	activityBucket       = []byte("subreddit_activity")
+
+	schemaVersionKey = []byte("schema_version")
+)
+
+// migrations are applied in order, each in its own transaction. The index of
+// a migration plus one is the schema version it produces, so existing entries
+// must never be reordered or edited — append a new one instead.
+var migrations = []func(tx *bbolt.Tx) error{
+	// 1: initial schema
+	func(tx *bbolt.Tx) error {
+		return createBuckets(tx,
+			usersBucket,
+			subredditsBucket,
+			postsBucket,
+			commentsBucket,
+			inboxBucket,
+			votesBucket,
+			subredditPostsBucket,
+			postCommentsBucket,
+		)
+	},
+	// 2: recount scores from the votes bucket, since earlier versions
+	// counted repeated votes more than once
+	recountVotes,
+	// 3: per-author and per-user indexes
+	addUserIndexes,
+	// 4: moderation logs
+	func(tx *bbolt.Tx) error {
+		return createBuckets(tx, modLogBucket)
+	},
+	// 5: unique subreddit names
+	addSubredditNames,
+	// 6: sent messages and messages by ID
+	addMessageIndexes,
+	// 7: votes by user
+	addUserVotes,
+	// 8: live posts and comments of each subreddit by time
+	addSubredditActivity,
+}
+
+// SchemaVersion is the version a freshly migrated database reports.
+var SchemaVersion = uint64(len(migrations))
+
+func createBuckets(tx *bbolt.Tx, names ...[]byte) error {
+	for _, name := range names {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return fmt.Errorf("create bucket %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// migrate brings the database up to SchemaVersion.
+func migrate(db *bbolt.DB) error {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if current > SchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", current, SchemaVersion)
+	}
+
+	for version := current; version < SchemaVersion; version++ {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			if err := migrations[version](tx); err != nil {
+				return err
+			}
+			return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(version+1))
+		})
+		if err != nil {
+			return fmt.Errorf("migration to version %d failed: %w", version+1, err)
+		}
+	}
+	return nil
+}
+
+func recountVotes(tx *bbolt.Tx) error {
+	ups := make(map[string]int32)
+	downs := make(map[string]int32)
+	err := tx.Bucket(votesBucket).ForEach(func(k, v []byte) error {
+		targetID, _, _ := bytes.Cut(k, []byte{0})
+		if decodeVote(v) {
+			ups[string(targetID)]++
+		} else {
+			downs[string(targetID)]++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	postKarma := make(map[string]int32)
+	commentKarma := make(map[string]int32)
+
+	var posts []*models.Post
+	err = tx.Bucket(postsBucket).ForEach(func(k, v []byte) error {
+		post := &models.Post{}
+		if err := json.Unmarshal(v, post); err != nil {
+			return err
+		}
+		post.Ups, post.Downs = ups[post.ID], downs[post.ID]
+		post.Karma = post.Ups - post.Downs
+		postKarma[post.AuthorID] += post.Karma
+		posts = append(posts, post)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		if err := putJSON(tx.Bucket(postsBucket), []byte(post.ID), post); err != nil {
+			return err
+		}
+	}
+
+	var comments []*models.Comment
+	err = tx.Bucket(commentsBucket).ForEach(func(k, v []byte) error {
+		comment := &models.Comment{}
+		if err := json.Unmarshal(v, comment); err != nil {
+			return err
+		}
+		comment.Ups, comment.Downs = ups[comment.ID], downs[comment.ID]
+		comment.Karma = comment.Ups - comment.Downs
+		commentKarma[comment.AuthorID] += comment.Karma
+		comments = append(comments, comment)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if err := putJSON(tx.Bucket(commentsBucket), []byte(comment.ID), comment); err != nil {
+			return err
+		}
+	}
+
+	var users []*models.User
+	err = tx.Bucket(usersBucket).ForEach(func(k, v []byte) error {
+		user := &models.User{}
+		if err := json.Unmarshal(v, user); err != nil {
+			return err
+		}
+		user.PostKarma, user.CommentKarma = postKarma[user.ID], commentKarma[user.ID]
+		user.Karma = user.PostKarma + user.CommentKarma
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := putJSON(tx.Bucket(usersBucket), []byte(user.ID), user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addUserIndexes(tx *bbolt.Tx) error {
+	if err := createBuckets(tx, authorPostsBucket, authorCommentsBucket, userSubredditsBucket); err != nil {
+		return err
+	}
+
+	err := tx.Bucket(postsBucket).ForEach(func(k, v []byte) error {
+		post := &models.Post{}
+		if err := json.Unmarshal(v, post); err != nil {
+			return err
+		}
+		return tx.Bucket(authorPostsBucket).Put(indexKey(post.AuthorID, post.ID), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Bucket(commentsBucket).ForEach(func(k, v []byte) error {
+		comment := &models.Comment{}
+		if err := json.Unmarshal(v, comment); err != nil {
+			return err
+		}
+		return tx.Bucket(authorCommentsBucket).Put(indexKey(comment.AuthorID, comment.ID), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(subredditsBucket).ForEach(func(k, v []byte) error {
+		subreddit := &models.Subreddit{}
+		if err := json.Unmarshal(v, subreddit); err != nil {
+			return err
+		}
+		for userID := range subreddit.Members {
+			if err := tx.Bucket(userSubredditsBucket).Put(indexKey(userID, subreddit.ID), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// addSubredditNames indexes existing subreddits by name. Names were not unique
+// before, so of several subreddits sharing a name only the first by ID can be
+// found by name.
+func addSubredditNames(tx *bbolt.Tx) error {
+	if err := createBuckets(tx, subredditNamesBucket); err != nil {
+		return err
+	}
+	names := tx.Bucket(subredditNamesBucket)
+	return tx.Bucket(subredditsBucket).ForEach(func(k, v []byte) error {
+		subreddit := &models.Subreddit{}
+		if err := json.Unmarshal(v, subreddit); err != nil {
+			return err
+		}
+		key := []byte(store.NameKey(subreddit.Name))
+		if names.Get(key) != nil {
+			return nil
+		}
+		return names.Put(key, k)
+	})
+}
+
+// addMessageIndexes indexes every inbox entry by message ID and under its
+// sender.
+func addMessageIndexes(tx *bbolt.Tx) error {
+	if err := createBuckets(tx, messageKeysBucket, sentBucket); err != nil {
+		return err
+	}
+
+	var messages []*models.DirectMessage
+	var keys [][]byte
+	err := tx.Bucket(inboxBucket).ForEach(func(k, v []byte) error {
+		message := &models.DirectMessage{}
+		if err := json.Unmarshal(v, message); err != nil {
+			return err
+		}
+		messages = append(messages, message)
+		keys = append(keys, append([]byte(nil), k...))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, message := range messages {
+		if err := indexMessage(tx, message, keys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addUserVotes indexes the votes bucket, keyed target then user, by user then
+// target.
+func addUserVotes(tx *bbolt.Tx) error {
+	if err := createBuckets(tx, userVotesBucket); err != nil {
+		return err
+	}
+	userVotes := tx.Bucket(userVotesBucket)
+	return tx.Bucket(votesBucket).ForEach(func(k, v []byte) error {
+		targetID, userID, _ := bytes.Cut(k, []byte{0})
+		return userVotes.Put(indexKey(string(userID), string(targetID)), v)
+	})
+}
+
+// addSubredditActivity indexes the live posts and comments of every
+// subreddit by creation time.
+func addSubredditActivity(tx *bbolt.Tx) error {
+	if err := createBuckets(tx, activityBucket); err != nil {
+		return err
+	}
+
+	var live []*models.Post
+	err := tx.Bucket(postsBucket).ForEach(func(k, v []byte) error {
+		post := &models.Post{}
+		if err := json.Unmarshal(v, post); err != nil {
+			return err
+		}
+		if store.LivePost(post) {
+			live = append(live, post)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, post := range live {
+		if err := setPostActivity(tx, post, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func schemaVersion(db *bbolt.DB) (uint64, error) {
+	var version uint64
+	err := db.View(func(tx *bbolt.Tx) error {
+		if raw := tx.Bucket(metaBucket).Get(schemaVersionKey); raw != nil {
+			version = binary.BigEndian.Uint64(raw)
+		}
+		return nil
+	})
+	return version, err
+}
+
+func encodeUint64(v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return buf
+}
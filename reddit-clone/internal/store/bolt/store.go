@@ -0,0 +1,953 @@
+// store/bolt/store.go
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// BoltStore is a store.Store persisted in a single bbolt database file.
+// Records are stored as JSON; secondary lookups use index buckets whose keys
+// are "<parent>\x00<child>" so they can be range-scanned by prefix.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the database file.
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
+
+// User operations
+func (b *BoltStore) CreateUser(user *models.User) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		users := tx.Bucket(usersBucket)
+		if users.Get([]byte(user.ID)) != nil {
+			return fmt.Errorf("user %w", store.ErrAlreadyExists)
+		}
+		return putJSON(users, []byte(user.ID), user)
+	})
+}
+
+func (b *BoltStore) GetUser(id string) (*models.User, error) {
+	user := &models.User{}
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		found, err := getJSON(tx.Bucket(usersBucket), []byte(id), user)
+		if err == nil && !found {
+			err = &store.NotFoundError{Kind: "user", ID: id}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (b *BoltStore) UpdateProfile(userID, displayName, bio string) error {
+	return b.setUser(userID, func(user *models.User) {
+		user.DisplayName, user.Bio = displayName, bio
+	})
+}
+
+func (b *BoltStore) AddKarma(userID string, postDelta, commentDelta int32) error {
+	return b.setUser(userID, func(user *models.User) {
+		user.PostKarma += postDelta
+		user.CommentKarma += commentDelta
+		user.Karma += postDelta + commentDelta
+	})
+}
+
+func (b *BoltStore) SetPassword(userID, hash string) error {
+	return b.setUser(userID, func(user *models.User) { user.Password = hash })
+}
+
+func (b *BoltStore) RevokeSessions(userID string, before int64) error {
+	return b.setUser(userID, func(user *models.User) { user.SessionsRevoked = before })
+}
+
+func (b *BoltStore) EndSession(userID, tokenID string, expiresAt, now int64) error {
+	return b.setUser(userID, func(user *models.User) {
+		store.ApplyLogout(user, tokenID, expiresAt, now)
+	})
+}
+
+func (b *BoltStore) DeleteUser(userID string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(usersBucket).Get([]byte(userID)) == nil {
+			return &store.NotFoundError{Kind: "user", ID: userID}
+		}
+		err := updateUser(tx, userID, func(user *models.User) {
+			user.Username, user.Password, user.DisplayName, user.Bio = "", "", "", ""
+			user.Deleted, user.LoggedOut = true, nil
+		})
+		if err != nil {
+			return err
+		}
+		return creditToDeleted(tx, userID)
+	})
+}
+
+func (b *BoltStore) CreditToDeleted(userID string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return creditToDeleted(tx, userID)
+	})
+}
+
+func creditToDeleted(tx *bbolt.Tx, userID string) error {
+	posts := tx.Bucket(postsBucket)
+	postIDs, err := indexChildren(tx.Bucket(authorPostsBucket), userID)
+	if err != nil {
+		return err
+	}
+	for _, postID := range postIDs {
+		post := &models.Post{}
+		if _, err := getJSON(posts, []byte(postID), post); err != nil {
+			return err
+		}
+		post.AuthorID = models.DeletedAuthor
+		if err := putJSON(posts, []byte(postID), post); err != nil {
+			return err
+		}
+		if err := moveIndex(tx.Bucket(authorPostsBucket), userID, models.DeletedAuthor, postID); err != nil {
+			return err
+		}
+	}
+
+	comments := tx.Bucket(commentsBucket)
+	commentIDs, err := indexChildren(tx.Bucket(authorCommentsBucket), userID)
+	if err != nil {
+		return err
+	}
+	for _, commentID := range commentIDs {
+		comment := &models.Comment{}
+		if _, err := getJSON(comments, []byte(commentID), comment); err != nil {
+			return err
+		}
+		comment.AuthorID = models.DeletedAuthor
+		if err := putJSON(comments, []byte(commentID), comment); err != nil {
+			return err
+		}
+		if err := moveIndex(tx.Bucket(authorCommentsBucket), userID, models.DeletedAuthor, commentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setUser is updateUser in its own transaction, failing for unknown users.
+func (b *BoltStore) setUser(id string, fn func(*models.User)) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(usersBucket).Get([]byte(id)) == nil {
+			return &store.NotFoundError{Kind: "user", ID: id}
+		}
+		return updateUser(tx, id, fn)
+	})
+}
+
+// Subreddit operations
+func (b *BoltStore) CreateSubreddit(subreddit *models.Subreddit) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		subreddits := tx.Bucket(subredditsBucket)
+		if subreddits.Get([]byte(subreddit.ID)) != nil {
+			return fmt.Errorf("subreddit %w", store.ErrAlreadyExists)
+		}
+		names := tx.Bucket(subredditNamesBucket)
+		name := []byte(store.NameKey(subreddit.Name))
+		if names.Get(name) != nil {
+			return fmt.Errorf("subreddit name %w", store.ErrAlreadyExists)
+		}
+		if err := names.Put(name, []byte(subreddit.ID)); err != nil {
+			return err
+		}
+		for userID := range subreddit.Members {
+			if err := tx.Bucket(userSubredditsBucket).Put(indexKey(userID, subreddit.ID), nil); err != nil {
+				return err
+			}
+		}
+		return putJSON(subreddits, []byte(subreddit.ID), subreddit)
+	})
+}
+
+func (b *BoltStore) GetSubreddit(id string) (*models.Subreddit, error) {
+	subreddit := &models.Subreddit{}
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		found, err := getJSON(tx.Bucket(subredditsBucket), []byte(id), subreddit)
+		if err == nil && !found {
+			err = &store.NotFoundError{Kind: "subreddit", ID: id}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subreddit, nil
+}
+
+func (b *BoltStore) GetSubredditByName(name string) (*models.Subreddit, error) {
+	subreddit := &models.Subreddit{}
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		id := tx.Bucket(subredditNamesBucket).Get([]byte(store.NameKey(name)))
+		if id == nil {
+			return &store.NotFoundError{Kind: "subreddit", ID: name}
+		}
+		_, err := getJSON(tx.Bucket(subredditsBucket), id, subreddit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subreddit, nil
+}
+
+func (b *BoltStore) ListSubreddits() ([]*models.Subreddit, error) {
+	subreddits := make([]*models.Subreddit, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(subredditsBucket).ForEach(func(k, v []byte) error {
+			subreddit := &models.Subreddit{}
+			if err := json.Unmarshal(v, subreddit); err != nil {
+				return err
+			}
+			subreddits = append(subreddits, subreddit)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortSubreddits(subreddits)
+	return subreddits, nil
+}
+
+func (b *BoltStore) JoinSubreddit(subredditID, userID string) error {
+	return b.updateSubreddit(subredditID, func(tx *bbolt.Tx, subreddit *models.Subreddit) error {
+		if subreddit.Members == nil {
+			subreddit.Members = make(map[string]bool)
+		}
+		subreddit.Members[userID] = true
+		return tx.Bucket(userSubredditsBucket).Put(indexKey(userID, subredditID), nil)
+	})
+}
+
+func (b *BoltStore) LeaveSubreddit(subredditID, userID string) error {
+	return b.updateSubreddit(subredditID, func(tx *bbolt.Tx, subreddit *models.Subreddit) error {
+		delete(subreddit.Members, userID)
+		return tx.Bucket(userSubredditsBucket).Delete(indexKey(userID, subredditID))
+	})
+}
+
+func (b *BoltStore) GetUserSubreddits(userID string) ([]string, error) {
+	subredditIDs := make([]string, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		return scanIndex(tx.Bucket(userSubredditsBucket), userID, func(subredditID []byte) error {
+			subredditIDs = append(subredditIDs, string(subredditID))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subredditIDs, nil
+}
+
+func (b *BoltStore) SetSubscribed(userID, subredditID string, joined bool) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		subscriptions := tx.Bucket(userSubredditsBucket)
+		if joined {
+			return subscriptions.Put(indexKey(userID, subredditID), nil)
+		}
+		return subscriptions.Delete(indexKey(userID, subredditID))
+	})
+}
+
+func (b *BoltStore) updateSubreddit(id string, apply func(*bbolt.Tx, *models.Subreddit) error) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		subreddits := tx.Bucket(subredditsBucket)
+		subreddit := &models.Subreddit{}
+		found, err := getJSON(subreddits, []byte(id), subreddit)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &store.NotFoundError{Kind: "subreddit", ID: id}
+		}
+		if err := apply(tx, subreddit); err != nil {
+			return err
+		}
+		return putJSON(subreddits, []byte(id), subreddit)
+	})
+}
+
+// Post operations
+func (b *BoltStore) CreatePost(post *models.Post) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		posts := tx.Bucket(postsBucket)
+		if posts.Get([]byte(post.ID)) != nil {
+			return fmt.Errorf("post %w", store.ErrAlreadyExists)
+		}
+		if err := putJSON(posts, []byte(post.ID), post); err != nil {
+			return err
+		}
+		if err := tx.Bucket(authorPostsBucket).Put(indexKey(post.AuthorID, post.ID), nil); err != nil {
+			return err
+		}
+		if store.LivePost(post) {
+			if err := setActivity(tx, post.SubredditID, post.Created, postActivity, post.ID, true); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(subredditPostsBucket).Put(indexKey(post.SubredditID, post.ID), nil)
+	})
+}
+
+func (b *BoltStore) GetPost(id string) (*models.Post, error) {
+	post := &models.Post{}
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		found, err := getJSON(tx.Bucket(postsBucket), []byte(id), post)
+		if err == nil && !found {
+			err = &store.NotFoundError{Kind: "post", ID: id}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (b *BoltStore) GetSubredditActivity(subredditID string, since int64) (int, int64, error) {
+	var recent int
+	var last int64
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		prefix := indexKey(subredditID, "")
+		c := tx.Bucket(activityBucket).Cursor()
+		// The subreddit's entries end where the next parent would begin.
+		k, _ := c.Seek(append([]byte(subredditID), 1))
+		if k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+		last = decodeActivityTime(k[len(prefix):])
+		for k, _ = c.Seek(indexKey(subredditID, string(encodeActivityTime(since)))); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			recent++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return recent, last, nil
+}
+
+func (b *BoltStore) GetSubredditPosts(subredditID string) ([]*models.Post, error) {
+	return b.indexedPosts(subredditPostsBucket, subredditID)
+}
+
+func (b *BoltStore) GetUserPosts(authorID string) ([]*models.Post, error) {
+	return b.indexedPosts(authorPostsBucket, authorID)
+}
+
+func (b *BoltStore) EditPost(id, content string, edited int64) error {
+	return b.updatePost(id, func(post *models.Post) { store.ApplyPostEdit(post, content, edited) })
+}
+
+func (b *BoltStore) DeletePost(id string) error {
+	return b.updatePost(id, func(post *models.Post) { post.Deleted = true })
+}
+
+// indexedPosts loads the posts listed under parent in an index bucket.
+func (b *BoltStore) indexedPosts(index []byte, parent string) ([]*models.Post, error) {
+	posts := make([]*models.Post, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		postBucket := tx.Bucket(postsBucket)
+		return scanIndex(tx.Bucket(index), parent, func(postID []byte) error {
+			post := &models.Post{}
+			if _, err := getJSON(postBucket, postID, post); err != nil {
+				return err
+			}
+			posts = append(posts, post)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortPosts(posts)
+	return posts, nil
+}
+
+// Comment operations
+func (b *BoltStore) AddComment(comment *models.Comment) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		comments := tx.Bucket(commentsBucket)
+		if comments.Get([]byte(comment.ID)) != nil {
+			return fmt.Errorf("comment %w", store.ErrAlreadyExists)
+		}
+		if err := putJSON(comments, []byte(comment.ID), comment); err != nil {
+			return err
+		}
+		if err := tx.Bucket(authorCommentsBucket).Put(indexKey(comment.AuthorID, comment.ID), nil); err != nil {
+			return err
+		}
+		if err := tx.Bucket(postCommentsBucket).Put(indexKey(comment.PostID, comment.ID), nil); err != nil {
+			return err
+		}
+		if !store.LiveComment(comment) {
+			return nil
+		}
+		return setCommentActivity(tx, comment, true)
+	})
+}
+
+func (b *BoltStore) GetComment(id string) (*models.Comment, error) {
+	comment := &models.Comment{}
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		found, err := getJSON(tx.Bucket(commentsBucket), []byte(id), comment)
+		if err == nil && !found {
+			err = &store.NotFoundError{Kind: "comment", ID: id}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (b *BoltStore) GetComments(postID string) ([]*models.Comment, error) {
+	return b.indexedComments(postCommentsBucket, postID)
+}
+
+func (b *BoltStore) GetUserComments(authorID string) ([]*models.Comment, error) {
+	return b.indexedComments(authorCommentsBucket, authorID)
+}
+
+func (b *BoltStore) EditComment(id, content string, edited int64) error {
+	return b.updateComment(id, func(comment *models.Comment) { store.ApplyCommentEdit(comment, content, edited) })
+}
+
+func (b *BoltStore) DeleteComment(id string) error {
+	return b.updateComment(id, func(comment *models.Comment) { comment.Deleted = true })
+}
+
+// indexedComments loads the comments listed under parent in an index bucket.
+func (b *BoltStore) indexedComments(index []byte, parent string) ([]*models.Comment, error) {
+	comments := make([]*models.Comment, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		commentBucket := tx.Bucket(commentsBucket)
+		return scanIndex(tx.Bucket(index), parent, func(commentID []byte) error {
+			comment := &models.Comment{}
+			if _, err := getJSON(commentBucket, commentID, comment); err != nil {
+				return err
+			}
+			comments = append(comments, comment)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortComments(comments)
+	return comments, nil
+}
+
+// Message operations
+func (b *BoltStore) SendMessage(message *models.DirectMessage) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(messageKeysBucket).Get([]byte(message.ID)) != nil {
+			return fmt.Errorf("message %w", store.ErrAlreadyExists)
+		}
+		inbox := tx.Bucket(inboxBucket)
+		// The bucket sequence keeps each inbox in delivery order.
+		seq, err := inbox.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := indexKey(message.ToID, string(encodeUint64(seq)))
+		if err := putJSON(inbox, key, message); err != nil {
+			return err
+		}
+		return indexMessage(tx, message, key)
+	})
+}
+
+// indexMessage records the inbox key of a message under its ID and in its
+// sender's sent index.
+func indexMessage(tx *bbolt.Tx, message *models.DirectMessage, inboxKey []byte) error {
+	if err := tx.Bucket(messageKeysBucket).Put([]byte(message.ID), inboxKey); err != nil {
+		return err
+	}
+	seq := inboxKey[len(message.ToID)+1:]
+	return tx.Bucket(sentBucket).Put(indexKey(message.FromID, string(seq)), inboxKey)
+}
+
+func (b *BoltStore) GetMessages(userID string) ([]*models.DirectMessage, error) {
+	messages := make([]*models.DirectMessage, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		prefix := indexKey(userID, "")
+		c := tx.Bucket(inboxBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			message := &models.DirectMessage{}
+			if err := json.Unmarshal(v, message); err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortMessages(messages)
+	return messages, nil
+}
+
+func (b *BoltStore) GetSentMessages(userID string) ([]*models.DirectMessage, error) {
+	return b.sentMessages(userID, func(*models.DirectMessage) bool { return true })
+}
+
+func (b *BoltStore) GetConversation(userID, otherID string) ([]*models.DirectMessage, error) {
+	received, err := b.GetMessages(userID)
+	if err != nil {
+		return nil, err
+	}
+	conversation := make([]*models.DirectMessage, 0)
+	for _, message := range received {
+		if message.FromID == otherID {
+			conversation = append(conversation, message)
+		}
+	}
+	// Messages to oneself are already in the inbox.
+	if userID != otherID {
+		sent, err := b.sentMessages(userID, func(message *models.DirectMessage) bool { return message.ToID == otherID })
+		if err != nil {
+			return nil, err
+		}
+		conversation = append(conversation, sent...)
+	}
+	store.SortMessages(conversation)
+	return conversation, nil
+}
+
+// sentMessages loads the messages userID sent that match keep.
+func (b *BoltStore) sentMessages(userID string, keep func(*models.DirectMessage) bool) ([]*models.DirectMessage, error) {
+	messages := make([]*models.DirectMessage, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		inbox := tx.Bucket(inboxBucket)
+		prefix := indexKey(userID, "")
+		c := tx.Bucket(sentBucket).Cursor()
+		for k, inboxKey := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, inboxKey = c.Next() {
+			message := &models.DirectMessage{}
+			if _, err := getJSON(inbox, inboxKey, message); err != nil {
+				return err
+			}
+			if keep(message) {
+				messages = append(messages, message)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortMessages(messages)
+	return messages, nil
+}
+
+func (b *BoltStore) MarkRead(userID string, messageIDs []string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		inbox := tx.Bucket(inboxBucket)
+		for _, id := range messageIDs {
+			inboxKey := tx.Bucket(messageKeysBucket).Get([]byte(id))
+			message := &models.DirectMessage{}
+			found, err := getJSON(inbox, inboxKey, message)
+			if err != nil {
+				return err
+			}
+			// Returning an error rolls back the messages already marked.
+			if !found || message.ToID != userID {
+				return &store.NotFoundError{Kind: "message", ID: id}
+			}
+			if message.Read {
+				continue
+			}
+			message.Read = true
+			if err := putJSON(inbox, append([]byte(nil), inboxKey...), message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Vote operations
+
+// Vote records a user's vote on a post or comment. Each user holds at most one
+// vote per target: repeating it is a no-op and switching direction replaces it.
+func (b *BoltStore) Vote(targetID, userID string, isUpvote bool) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		votes := tx.Bucket(votesBucket)
+		key := indexKey(targetID, userID)
+
+		previous := votes.Get(key)
+		if previous != nil && decodeVote(previous) == isUpvote {
+			return nil
+		}
+		if previous != nil {
+			if err := applyVote(tx, targetID, decodeVote(previous), -1); err != nil {
+				return err
+			}
+		}
+
+		if err := votes.Put(key, encodeVote(isUpvote)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(userVotesBucket).Put(indexKey(userID, targetID), encodeVote(isUpvote)); err != nil {
+			return err
+		}
+		return applyVote(tx, targetID, isUpvote, 1)
+	})
+}
+
+// ClearVote retracts a user's vote. Clearing a vote that was never cast is a no-op.
+func (b *BoltStore) ClearVote(targetID, userID string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		votes := tx.Bucket(votesBucket)
+		key := indexKey(targetID, userID)
+
+		previous := votes.Get(key)
+		if previous == nil {
+			return nil
+		}
+		isUpvote := decodeVote(previous)
+		if err := votes.Delete(key); err != nil {
+			return err
+		}
+		if err := tx.Bucket(userVotesBucket).Delete(indexKey(userID, targetID)); err != nil {
+			return err
+		}
+		return applyVote(tx, targetID, isUpvote, -1)
+	})
+}
+
+func (b *BoltStore) GetUserVotes(userID string) (map[string]bool, error) {
+	votes := make(map[string]bool)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		prefix := indexKey(userID, "")
+		c := tx.Bucket(userVotesBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			votes[string(k[len(prefix):])] = decodeVote(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+// applyVote adds (sign 1) or removes (sign -1) one vote from the tally of the
+// target (post or comment).
+func applyVote(tx *bbolt.Tx, targetID string, isUpvote bool, sign int32) error {
+	delta := sign
+	if !isUpvote {
+		delta = -sign
+	}
+
+	post := &models.Post{}
+	found, err := getJSON(tx.Bucket(postsBucket), []byte(targetID), post)
+	if err != nil {
+		return err
+	}
+	if found {
+		if isUpvote {
+			post.Ups += sign
+		} else {
+			post.Downs += sign
+		}
+		post.Karma += delta
+		return putJSON(tx.Bucket(postsBucket), []byte(targetID), post)
+	}
+
+	comment := &models.Comment{}
+	found, err = getJSON(tx.Bucket(commentsBucket), []byte(targetID), comment)
+	if err != nil || !found {
+		return err
+	}
+	if isUpvote {
+		comment.Ups += sign
+	} else {
+		comment.Downs += sign
+	}
+	comment.Karma += delta
+	return putJSON(tx.Bucket(commentsBucket), []byte(targetID), comment)
+}
+
+// Moderation operations
+func (b *BoltStore) AddModerator(subredditID, userID string) error {
+	return b.updateSubreddit(subredditID, func(tx *bbolt.Tx, subreddit *models.Subreddit) error {
+		if subreddit.Moderators == nil {
+			subreddit.Moderators = make(map[string]bool)
+		}
+		subreddit.Moderators[userID] = true
+		return nil
+	})
+}
+
+func (b *BoltStore) SetBanned(subredditID, userID string, banned bool) error {
+	return b.updateSubreddit(subredditID, func(tx *bbolt.Tx, subreddit *models.Subreddit) error {
+		if !banned {
+			delete(subreddit.Banned, userID)
+			return nil
+		}
+		if subreddit.Banned == nil {
+			subreddit.Banned = make(map[string]bool)
+		}
+		subreddit.Banned[userID] = true
+		return nil
+	})
+}
+
+func (b *BoltStore) RemovePost(id string) error {
+	return b.updatePost(id, func(post *models.Post) { post.Removed = true })
+}
+
+func (b *BoltStore) SetPostLocked(id string, locked bool) error {
+	return b.updatePost(id, func(post *models.Post) { post.Locked = locked })
+}
+
+func (b *BoltStore) updatePost(id string, apply func(*models.Post)) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		posts := tx.Bucket(postsBucket)
+		post := &models.Post{}
+		found, err := getJSON(posts, []byte(id), post)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &store.NotFoundError{Kind: "post", ID: id}
+		}
+		wasLive := store.LivePost(post)
+		apply(post)
+		if live := store.LivePost(post); live != wasLive {
+			if err := setPostActivity(tx, post, live); err != nil {
+				return err
+			}
+		}
+		return putJSON(posts, []byte(id), post)
+	})
+}
+
+func (b *BoltStore) RemoveComment(id string) error {
+	return b.updateComment(id, func(comment *models.Comment) { comment.Removed = true })
+}
+
+func (b *BoltStore) updateComment(id string, apply func(*models.Comment)) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		comments := tx.Bucket(commentsBucket)
+		comment := &models.Comment{}
+		found, err := getJSON(comments, []byte(id), comment)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &store.NotFoundError{Kind: "comment", ID: id}
+		}
+		wasLive := store.LiveComment(comment)
+		apply(comment)
+		if live := store.LiveComment(comment); live != wasLive {
+			if err := setCommentActivity(tx, comment, live); err != nil {
+				return err
+			}
+		}
+		return putJSON(comments, []byte(id), comment)
+	})
+}
+
+func (b *BoltStore) AddModAction(action *models.ModAction) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		log := tx.Bucket(modLogBucket)
+		seq, err := log.NextSequence()
+		if err != nil {
+			return err
+		}
+		return putJSON(log, indexKey(action.SubredditID, string(encodeUint64(seq))), action)
+	})
+}
+
+func (b *BoltStore) GetModLog(subredditID string) ([]*models.ModAction, error) {
+	actions := make([]*models.ModAction, 0)
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		prefix := indexKey(subredditID, "")
+		c := tx.Bucket(modLogBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			action := &models.ModAction{}
+			if err := json.Unmarshal(v, action); err != nil {
+				return err
+			}
+			actions = append(actions, action)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.SortModActions(actions)
+	return actions, nil
+}
+
+func encodeVote(isUpvote bool) []byte {
+	if isUpvote {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+func decodeVote(value []byte) bool {
+	return len(value) > 0 && value[0] == 1
+}
+
+// updateUser applies fn to a stored user; unknown users are ignored.
+func updateUser(tx *bbolt.Tx, id string, fn func(*models.User)) error {
+	users := tx.Bucket(usersBucket)
+	user := &models.User{}
+	found, err := getJSON(users, []byte(id), user)
+	if err != nil || !found {
+		return err
+	}
+	fn(user)
+	return putJSON(users, []byte(id), user)
+}
+
+func indexKey(parent, child string) []byte {
+	key := make([]byte, 0, len(parent)+1+len(child))
+	key = append(key, parent...)
+	key = append(key, 0)
+	return append(key, child...)
+}
+
+// scanIndex calls fn with the child part of every index key under parent.
+func scanIndex(bucket *bbolt.Bucket, parent string, fn func(child []byte) error) error {
+	prefix := indexKey(parent, "")
+	c := bucket.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		if err := fn(k[len(prefix):]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexChildren lists the children under parent in an index bucket, so the
+// caller may change the index while going through them.
+func indexChildren(bucket *bbolt.Bucket, parent string) ([]string, error) {
+	var children []string
+	err := scanIndex(bucket, parent, func(child []byte) error {
+		children = append(children, string(child))
+		return nil
+	})
+	return children, err
+}
+
+// moveIndex moves child from one parent to another in an index bucket.
+func moveIndex(bucket *bbolt.Bucket, from, to, child string) error {
+	if err := bucket.Delete(indexKey(from, child)); err != nil {
+		return err
+	}
+	return bucket.Put(indexKey(to, child), nil)
+}
+
+// Kinds of activity entries. An entry's key is indexKey(subredditID, time +
+// kind + ID), with the time big-endian so that entries sort by it.
+const (
+	postActivity    = 'p'
+	commentActivity = 'c'
+)
+
+// setActivity adds an entry to or removes it from a subreddit's activity.
+func setActivity(tx *bbolt.Tx, subredditID string, created int64, kind byte, id string, live bool) error {
+	child := append(encodeActivityTime(created), kind)
+	key := indexKey(subredditID, string(append(child, id...)))
+	if live {
+		return tx.Bucket(activityBucket).Put(key, nil)
+	}
+	return tx.Bucket(activityBucket).Delete(key)
+}
+
+// setPostActivity adds or removes a post and its live comments, which count
+// only while the post is live.
+func setPostActivity(tx *bbolt.Tx, post *models.Post, live bool) error {
+	if err := setActivity(tx, post.SubredditID, post.Created, postActivity, post.ID, live); err != nil {
+		return err
+	}
+	comments := tx.Bucket(commentsBucket)
+	return scanIndex(tx.Bucket(postCommentsBucket), post.ID, func(commentID []byte) error {
+		comment := &models.Comment{}
+		if _, err := getJSON(comments, commentID, comment); err != nil || !store.LiveComment(comment) {
+			return err
+		}
+		return setActivity(tx, post.SubredditID, comment.Created, commentActivity, comment.ID, live)
+	})
+}
+
+// setCommentActivity adds or removes a comment if its post is live.
+func setCommentActivity(tx *bbolt.Tx, comment *models.Comment, live bool) error {
+	post := &models.Post{}
+	found, err := getJSON(tx.Bucket(postsBucket), []byte(comment.PostID), post)
+	if err != nil || !found || !store.LivePost(post) {
+		return err
+	}
+	return setActivity(tx, post.SubredditID, comment.Created, commentActivity, comment.ID, live)
+}
+
+// encodeActivityTime encodes unix seconds so that byte order is time order.
+// Flipping the sign bit puts negative times first.
+func encodeActivityTime(t int64) []byte {
+	return encodeUint64(uint64(t) ^ 1<<63)
+}
+
+func decodeActivityTime(child []byte) int64 {
+	return int64(binary.BigEndian.Uint64(child[:8]) ^ 1<<63)
+}
+
+func getJSON(bucket *bbolt.Bucket, key []byte, v interface{}) (bool, error) {
+	raw := bucket.Get(key)
+	if raw == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+func putJSON(bucket *bbolt.Bucket, key []byte, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, raw)
+}
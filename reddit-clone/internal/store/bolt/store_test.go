@@ -0,0 +1,275 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.etcd.io/bbolt"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+	"reddit-clone/internal/store/storetest"
+)
+
+func openTestStore(t *testing.T, path string) *BoltStore {
+	t.Helper()
+	s, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open bolt store: %v", err)
+	}
+	return s
+}
+
+func TestBoltStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s := openTestStore(t, filepath.Join(t.TempDir(), "reddit.db"))
+		t.Cleanup(func() { s.Close() })
+		return s
+	})
+}
+
+func TestBoltStoreSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	s := openTestStore(t, path)
+	if err := s.CreateUser(&models.User{ID: "user1", Username: "testuser"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := s.CreateSubreddit(&models.Subreddit{ID: "sub1", Name: "golang"}); err != nil {
+		t.Fatalf("Failed to create subreddit: %v", err)
+	}
+	if err := s.JoinSubreddit("sub1", "user1"); err != nil {
+		t.Fatalf("Failed to join subreddit: %v", err)
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "user1", Title: "Persisted"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.Vote("post1", "user1", true); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	if _, err := reopened.GetUser("user1"); err != nil {
+		t.Errorf("Expected user to survive reopen: %v", err)
+	}
+	subreddit, err := reopened.GetSubreddit("sub1")
+	if err != nil {
+		t.Fatalf("Expected subreddit to survive reopen: %v", err)
+	}
+	if !subreddit.Members["user1"] {
+		t.Error("Expected membership to survive reopen")
+	}
+	posts, err := reopened.GetSubredditPosts("sub1")
+	if err != nil || len(posts) != 1 {
+		t.Fatalf("Expected 1 post after reopen, got %d (%v)", len(posts), err)
+	}
+	if posts[0].Karma != 1 {
+		t.Errorf("Expected karma 1 after reopen, got %d", posts[0].Karma)
+	}
+}
+
+func TestBoltStoreMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	s := openTestStore(t, path)
+	version, err := schemaVersion(s.db)
+	if err != nil {
+		t.Fatalf("Failed to read schema version: %v", err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+	s.Close()
+
+	// Migrating an up-to-date database must be a no-op.
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+	if version, _ := schemaVersion(reopened.db); version != SchemaVersion {
+		t.Errorf("Expected schema version %d after reopen, got %d", SchemaVersion, version)
+	}
+}
+
+func TestBoltStoreRecountsVotesOnUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	s := openTestStore(t, path)
+	if err := s.CreateUser(&models.User{ID: "user1", Username: "alice"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "user1"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.Vote("post1", "user2", true); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+
+	// Simulate a version 1 database where the same vote was counted five times.
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := putJSON(tx.Bucket(postsBucket), []byte("post1"), &models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "user1", Karma: 5}); err != nil {
+			return err
+		}
+		if err := putJSON(tx.Bucket(usersBucket), []byte("user1"), &models.User{ID: "user1", Username: "alice", Karma: 5}); err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(1))
+	})
+	if err != nil {
+		t.Fatalf("Failed to downgrade database: %v", err)
+	}
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	post, err := reopened.GetPost("post1")
+	if err != nil {
+		t.Fatalf("Failed to get post: %v", err)
+	}
+	if post.Karma != 1 || post.Ups != 1 {
+		t.Errorf("Expected karma 1 from a single upvote, got %d (%d ups)", post.Karma, post.Ups)
+	}
+	user, err := reopened.GetUser("user1")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if user.Karma != 1 || user.PostKarma != 1 {
+		t.Errorf("Expected author karma 1, got %d (post karma %d)", user.Karma, user.PostKarma)
+	}
+}
+
+func TestBoltStoreIndexesSubredditNamesOnUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	// Simulate a version 4 database, whose subreddit names were not unique.
+	s := openTestStore(t, path)
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		for _, id := range []string{"b", "a"} {
+			if err := putJSON(tx.Bucket(subredditsBucket), []byte(id), &models.Subreddit{ID: id, Name: "GoLang"}); err != nil {
+				return err
+			}
+		}
+		if err := tx.DeleteBucket(subredditNamesBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(4))
+	})
+	if err != nil {
+		t.Fatalf("Failed to downgrade database: %v", err)
+	}
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	subreddit, err := reopened.GetSubredditByName("golang")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit by name: %v", err)
+	}
+	if subreddit.ID != "a" {
+		t.Errorf("Expected the first subreddit by ID, got %s", subreddit.ID)
+	}
+	if err := reopened.CreateSubreddit(&models.Subreddit{ID: "c", Name: "golang"}); err == nil {
+		t.Error("Expected an existing name to be rejected after the upgrade")
+	}
+}
+
+func TestBoltStoreIndexesMessagesOnUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	// Simulate a version 5 database, which kept messages only in inboxes.
+	s := openTestStore(t, path)
+	if err := s.SendMessage(&models.DirectMessage{ID: "m1", FromID: "alice", ToID: "bob", Timestamp: 1}); err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{messageKeysBucket, sentBucket} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(5))
+	})
+	if err != nil {
+		t.Fatalf("Failed to downgrade database: %v", err)
+	}
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	sent, err := reopened.GetSentMessages("alice")
+	if err != nil || len(sent) != 1 || sent[0].ID != "m1" {
+		t.Fatalf("Expected alice's sent message after the upgrade, got %v (%v)", sent, err)
+	}
+	if err := reopened.MarkRead("bob", []string{"m1"}); err != nil {
+		t.Errorf("Failed to mark an upgraded message read: %v", err)
+	}
+}
+
+func TestBoltStoreIndexesUserVotesOnUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	// Simulate a version 6 database, which kept votes only by target.
+	s := openTestStore(t, path)
+	if err := s.Vote("post1", "alice", false); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(userVotesBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(6))
+	})
+	if err != nil {
+		t.Fatalf("Failed to downgrade database: %v", err)
+	}
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	votes, err := reopened.GetUserVotes("alice")
+	if err != nil || len(votes) != 1 || votes["post1"] {
+		t.Fatalf("Expected alice's downvote after the upgrade, got %v (%v)", votes, err)
+	}
+}
+
+func TestBoltStoreIndexesActivityOnUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reddit.db")
+
+	// Simulate a version 7 database, which had no activity index.
+	s := openTestStore(t, path)
+	for _, err := range []error{
+		s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", Created: 10}),
+		s.CreatePost(&models.Post{ID: "post2", SubredditID: "sub1", Created: 20, Removed: true}),
+		s.AddComment(&models.Comment{ID: "c1", PostID: "post1", Created: 30}),
+		s.AddComment(&models.Comment{ID: "c2", PostID: "post2", Created: 40}),
+	} {
+		if err != nil {
+			t.Fatalf("Failed to fill the store: %v", err)
+		}
+	}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(activityBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, encodeUint64(7))
+	})
+	if err != nil {
+		t.Fatalf("Failed to downgrade database: %v", err)
+	}
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	recent, last, err := reopened.GetSubredditActivity("sub1", 0)
+	if err != nil || recent != 2 || last != 30 {
+		t.Fatalf("Expected post1 and c1 with the latest at 30, got %d, %d (%v)", recent, last, err)
+	}
+}
@@ -0,0 +1,33 @@
+// store/edit.go
+package store
+
+import "reddit-clone/internal/models"
+
+// ApplyPostEdit replaces the content of post, moving the current version to
+// its History. History is copied rather than appended to, because it may be
+// shared with a snapshot handed out earlier.
+func ApplyPostEdit(post *models.Post, content string, edited int64) {
+	post.History = revise(post.History, post.Content, VersionTime(post.Created, post.Edited))
+	post.Content, post.Edited = content, edited
+}
+
+// ApplyCommentEdit is ApplyPostEdit for comments.
+func ApplyCommentEdit(comment *models.Comment, content string, edited int64) {
+	comment.History = revise(comment.History, comment.Content, VersionTime(comment.Created, comment.Edited))
+	comment.Content, comment.Edited = content, edited
+}
+
+// VersionTime returns when the current version of a post or comment was
+// written: when it was last edited, or else created.
+func VersionTime(created, edited int64) int64 {
+	if edited != 0 {
+		return edited
+	}
+	return created
+}
+
+func revise(history []models.Revision, content string, created int64) []models.Revision {
+	revised := make([]models.Revision, len(history), len(history)+1)
+	copy(revised, history)
+	return append(revised, models.Revision{Content: content, Created: created})
+}
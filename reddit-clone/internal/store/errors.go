@@ -0,0 +1,51 @@
+// store/errors.go
+package store
+
+import "errors"
+
+// Store errors match one of these with errors.Is, so callers can tell why a
+// call failed without parsing its message. Any other error is a failure of
+// the store itself, such as a disk error.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrForbidden and ErrInvalid are not returned by stores, but classify
+	// the engine's checks of a request the same way.
+	ErrForbidden = errors.New("forbidden")
+	ErrInvalid   = errors.New("invalid")
+)
+
+// NotFoundError reports that a Store has no user, subreddit, post, comment or
+// message with the given ID, or no subreddit with the given name. It matches
+// ErrNotFound.
+type NotFoundError struct {
+	Kind string // e.g. "user" or "post"
+	ID   string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Kind + " " + e.ID + " not found"
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// ReferenceError reports a write that refers to an existing entity it may
+// not use, such as a parent comment on another post.
+type ReferenceError struct {
+	Field  string // the request field holding the reference, e.g. "parent_id"
+	ID     string
+	Reason string
+	// Err is ErrForbidden if the user may not use the entity, and ErrInvalid
+	// if nobody may.
+	Err error
+}
+
+func (e *ReferenceError) Error() string {
+	return e.Field + " " + e.ID + ": " + e.Reason
+}
+
+func (e *ReferenceError) Unwrap() error {
+	return e.Err
+}
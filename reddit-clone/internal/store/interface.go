@@ -3,30 +3,101 @@ package store
 
 import "reddit-clone/internal/models"
 
+// Store is the persistence layer of the engine. Listing methods return their
+// items oldest first, ties broken by ID (see SortPosts), and never nil.
+// Returned models are snapshots: later writes do not change them, so actors
+// may read them concurrently. Subreddit names are unique regardless of case.
+// Reads and updates of a missing entity fail with a *NotFoundError.
 type Store interface {
 	// User operations
 	CreateUser(user *models.User) error
 	GetUser(id string) (*models.User, error)
+	UpdateProfile(userID, displayName, bio string) error
+	// SetPassword replaces the password hash of a user.
+	SetPassword(userID, hash string) error
+	// DeleteUser clears the profile and password of a user, marks it
+	// Deleted and credits its posts and comments to models.DeletedAuthor.
+	// The ID stays taken.
+	DeleteUser(userID string) error
+	// CreditToDeleted credits the posts and comments of a user to
+	// models.DeletedAuthor, on the members that store them but not the user
+	// in cluster mode. Unknown users have none.
+	CreditToDeleted(userID string) error
+	// RevokeSessions invalidates the sessions of a user issued before the
+	// given time (unix nanoseconds).
+	RevokeSessions(userID string, before int64) error
+	// EndSession records that the session token tokenID of a user, valid
+	// until expiresAt (unix seconds), was logged out. See store.ApplyLogout.
+	EndSession(userID, tokenID string, expiresAt, now int64) error
 
 	// Subreddit operations
 	CreateSubreddit(subreddit *models.Subreddit) error
 	GetSubreddit(id string) (*models.Subreddit, error)
+	// GetSubredditByName matches names case-insensitively; see NameKey.
+	GetSubredditByName(name string) (*models.Subreddit, error)
+	ListSubreddits() ([]*models.Subreddit, error)
 	JoinSubreddit(subredditID, userID string) error
 	LeaveSubreddit(subredditID, userID string) error
+	GetUserSubreddits(userID string) ([]string, error)
+	// SetSubscribed records on the user's side only whether userID has
+	// joined subredditID, on the member that stores the user in cluster
+	// mode. JoinSubreddit and LeaveSubreddit record both sides.
+	SetSubscribed(userID, subredditID string, joined bool) error
+	// GetSubredditActivity counts the live posts and comments of a
+	// subreddit (see LivePost) created at or after since, in unix seconds,
+	// and returns when the latest of them was created. Stores keep them
+	// indexed by time, so this does not load the subreddit's content.
+	GetSubredditActivity(subredditID string, since int64) (recent int, last int64, err error)
 
 	// Post operations
 	CreatePost(post *models.Post) error
 	GetPost(id string) (*models.Post, error)
 	GetSubredditPosts(subredditID string) ([]*models.Post, error)
+	GetUserPosts(authorID string) ([]*models.Post, error)
+	// EditPost replaces the content of a post, keeping the previous version
+	// in its History (see ApplyPostEdit). DeletePost marks it deleted by its
+	// author; like a removed post it stays in the store.
+	EditPost(id, content string, edited int64) error
+	DeletePost(id string) error
 
 	// Comment operations
 	AddComment(comment *models.Comment) error
+	GetComment(id string) (*models.Comment, error)
 	GetComments(postID string) ([]*models.Comment, error)
+	GetUserComments(authorID string) ([]*models.Comment, error)
+	EditComment(id, content string, edited int64) error
+	DeleteComment(id string) error
 
 	// Message operations
 	SendMessage(message *models.DirectMessage) error
+	// GetMessages returns the messages userID received, GetSentMessages
+	// those they sent and GetConversation both directions between two
+	// users.
 	GetMessages(userID string) ([]*models.DirectMessage, error)
+	GetSentMessages(userID string) ([]*models.DirectMessage, error)
+	GetConversation(userID, otherID string) ([]*models.DirectMessage, error)
+	// MarkRead marks messages received by userID as read. It fails without
+	// marking any if one of them was not sent to userID.
+	MarkRead(userID string, messageIDs []string) error
 
-	// Vote operations
+	// Vote operations. Vote and ClearVote change the tally of the post or
+	// comment only; in cluster mode its author may be stored on another
+	// member, so the engine credits their karma with AddKarma there.
 	Vote(targetID, userID string, isUpvote bool) error
+	ClearVote(targetID, userID string) error
+	// AddKarma adds to the post and comment karma of a user, and their sum
+	// to Karma.
+	AddKarma(userID string, postDelta, commentDelta int32) error
+	// GetUserVotes maps the targets userID voted on to whether the vote was
+	// an upvote.
+	GetUserVotes(userID string) (map[string]bool, error)
+
+	// Moderation operations
+	AddModerator(subredditID, userID string) error
+	SetBanned(subredditID, userID string, banned bool) error
+	RemovePost(id string) error
+	RemoveComment(id string) error
+	SetPostLocked(id string, locked bool) error
+	AddModAction(action *models.ModAction) error
+	GetModLog(subredditID string) ([]*models.ModAction, error)
 }
@@ -0,0 +1,621 @@
+// store/memory/durable.go
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+const (
+	snapshotFile = "snapshot.json"
+	logFile      = "wal.log"
+)
+
+// Names of the logged operations. They are persisted, so never rename one.
+const (
+	opCreateUser      = "CreateUser"
+	opCreateSubreddit = "CreateSubreddit"
+	opJoinSubreddit   = "JoinSubreddit"
+	opLeaveSubreddit  = "LeaveSubreddit"
+	opCreatePost      = "CreatePost"
+	opAddComment      = "AddComment"
+	opSendMessage     = "SendMessage"
+	opMarkRead        = "MarkRead"
+	opVote            = "Vote"
+	opClearVote       = "ClearVote"
+	opAddKarma        = "AddKarma"
+	opSetSubscribed   = "SetSubscribed"
+	opAddModerator    = "AddModerator"
+	opSetBanned       = "SetBanned"
+	opRemovePost      = "RemovePost"
+	opRemoveComment   = "RemoveComment"
+	opSetPostLocked   = "SetPostLocked"
+	opAddModAction    = "AddModAction"
+	opEditPost        = "EditPost"
+	opDeletePost      = "DeletePost"
+	opEditComment     = "EditComment"
+	opDeleteComment   = "DeleteComment"
+	opUpdateProfile   = "UpdateProfile"
+	opSetPassword     = "SetPassword"
+	opDeleteUser      = "DeleteUser"
+	opCreditToDeleted = "CreditToDeleted"
+	opRevokeSessions  = "RevokeSessions"
+	opEndSession      = "EndSession"
+)
+
+var errUnknownOperation = errors.New("unknown logged operation")
+
+type DurableOptions struct {
+	// SnapshotEvery takes a snapshot and compacts the log after this many
+	// logged writes. Zero disables automatic snapshots.
+	SnapshotEvery int
+	// SyncWrites fsyncs the log after every record. Without it a crash may
+	// lose the most recent writes, but never corrupts older ones.
+	SyncWrites bool
+}
+
+// DurableStore is a MemoryStore whose writes are appended to a write-ahead
+// log before they are applied. Reads are served straight from memory. On open
+// the store is rebuilt from the latest snapshot plus the log tail.
+type DurableStore struct {
+	*MemoryStore
+
+	dir        string
+	opts       DurableOptions
+	log        *writeAheadLog
+	seq        uint64 // sequence number of the last logged write
+	sinceSnap  int
+	writeMutex sync.Mutex
+}
+
+type snapshotFileHeader struct {
+	Seq uint64 `json:"seq"`
+}
+
+// userArgs changes the account of a user.
+type userArgs struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+	Password    string `json:"password,omitempty"` // bcrypt hash
+}
+
+// sessionArgs revokes sessions of a user.
+type sessionArgs struct {
+	UserID    string `json:"user_id"`
+	Before    int64  `json:"before,omitempty"` // unix nanoseconds
+	TokenID   string `json:"token_id,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+	Now       int64  `json:"now,omitempty"`
+}
+
+type membershipArgs struct {
+	SubredditID string `json:"subreddit_id"`
+	UserID      string `json:"user_id"`
+	Joined      bool   `json:"joined,omitempty"` // SetSubscribed only
+}
+
+type readArgs struct {
+	UserID     string   `json:"user_id"`
+	MessageIDs []string `json:"message_ids"`
+}
+
+type banArgs struct {
+	SubredditID string `json:"subreddit_id"`
+	UserID      string `json:"user_id"`
+	Banned      bool   `json:"banned"`
+}
+
+// contentArgs names the post or comment a moderation or delete operation
+// applies to.
+type contentArgs struct {
+	ID     string `json:"id"`
+	Locked bool   `json:"locked,omitempty"`
+}
+
+// editArgs is an edit of a post or comment by its author.
+type editArgs struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Edited  int64  `json:"edited"`
+}
+
+type voteArgs struct {
+	TargetID string `json:"target_id"`
+	UserID   string `json:"user_id"`
+	IsUpvote bool   `json:"is_upvote"`
+	// TallyOnly marks votes logged since AddKarma credits the author.
+	// Replaying an older vote credits the author like it did when logged.
+	TallyOnly bool `json:"tally_only,omitempty"`
+}
+
+type karmaArgs struct {
+	UserID       string `json:"user_id"`
+	PostKarma    int32  `json:"post_karma,omitempty"`
+	CommentKarma int32  `json:"comment_karma,omitempty"`
+}
+
+func OpenDurableStore(dir string, opts DurableOptions) (*DurableStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	d := &DurableStore{
+		MemoryStore: NewMemoryStore(),
+		dir:         dir,
+		opts:        opts,
+	}
+	if err := d.recover(); err != nil {
+		return nil, err
+	}
+
+	wal, err := openWriteAheadLog(filepath.Join(dir, logFile), opts.SyncWrites)
+	if err != nil {
+		return nil, err
+	}
+	d.log = wal
+	return d, nil
+}
+
+// recover loads the snapshot, replays newer log records and cuts off any
+// torn record left at the end of the log by a crash.
+func (d *DurableStore) recover() error {
+	if err := d.loadSnapshotFile(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(d.dir, logFile)
+	records, validSize, err := readWriteAheadLog(path)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// Records already covered by the snapshot survive a crash between
+		// writing the snapshot and compacting the log; skip them.
+		if record.Seq <= d.seq {
+			continue
+		}
+		// Writes that failed originally were logged as well and fail the
+		// same way here without changing anything.
+		if err := d.apply(record); errors.Is(err, errUnknownOperation) {
+			return fmt.Errorf("replay record %d: %w", record.Seq, err)
+		}
+		d.seq = record.Seq
+		d.sinceSnap++
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > validSize {
+		return os.Truncate(path, validSize)
+	}
+	return nil
+}
+
+func (d *DurableStore) loadSnapshotFile() error {
+	raw, err := os.ReadFile(filepath.Join(d.dir, snapshotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The file is a header line followed by the encoded store.
+	headerLine, body, found := bytes.Cut(raw, []byte("\n"))
+	if !found {
+		return fmt.Errorf("snapshot %s is malformed", snapshotFile)
+	}
+	header := &snapshotFileHeader{}
+	if err := json.Unmarshal(headerLine, header); err != nil {
+		return fmt.Errorf("snapshot header: %w", err)
+	}
+	if err := d.MemoryStore.loadSnapshot(bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("snapshot body: %w", err)
+	}
+	d.seq = header.Seq
+	return nil
+}
+
+// Snapshot writes the full store to disk and compacts the log.
+func (d *DurableStore) Snapshot() error {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+	return d.snapshotLocked()
+}
+
+func (d *DurableStore) snapshotLocked() error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(&snapshotFileHeader{Seq: d.seq}); err != nil {
+		return err
+	}
+	if err := d.MemoryStore.writeSnapshot(&buf); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it into place so a crash never
+	// leaves a half-written snapshot behind.
+	path := filepath.Join(d.dir, snapshotFile)
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, buf.Bytes()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	if err := syncDir(d.dir); err != nil {
+		return err
+	}
+
+	if err := d.log.reset(); err != nil {
+		return err
+	}
+	d.sinceSnap = 0
+	return nil
+}
+
+// Close takes a final snapshot and releases the log.
+func (d *DurableStore) Close() error {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+
+	if err := d.snapshotLocked(); err != nil {
+		d.log.close()
+		return err
+	}
+	return d.log.close()
+}
+
+// write logs an operation and then applies it to the in-memory store.
+func (d *DurableStore) write(op string, args interface{}) error {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	record := &logRecord{Seq: d.seq + 1, Op: op, Data: data}
+	if err := d.log.append(record); err != nil {
+		return fmt.Errorf("write-ahead log: %w", err)
+	}
+	d.seq = record.Seq
+	d.sinceSnap++
+
+	// A failed operation is still logged; replaying it fails the same way
+	// and leaves the state untouched.
+	applyErr := d.apply(record)
+
+	// The write is already durable in the log, so a failed snapshot is
+	// only logged and retried after the next write.
+	if d.opts.SnapshotEvery > 0 && d.sinceSnap >= d.opts.SnapshotEvery {
+		if err := d.snapshotLocked(); err != nil {
+			log.Printf("memory store: snapshot failed: %v", err)
+		}
+	}
+	return applyErr
+}
+
+// apply executes a logged operation against the in-memory store.
+func (d *DurableStore) apply(record *logRecord) error {
+	switch record.Op {
+	case opCreateUser:
+		user := &models.User{}
+		if err := json.Unmarshal(record.Data, user); err != nil {
+			return err
+		}
+		return d.MemoryStore.CreateUser(user)
+	case opUpdateProfile, opSetPassword, opDeleteUser, opCreditToDeleted:
+		args := &userArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		switch record.Op {
+		case opUpdateProfile:
+			return d.MemoryStore.UpdateProfile(args.UserID, args.DisplayName, args.Bio)
+		case opSetPassword:
+			return d.MemoryStore.SetPassword(args.UserID, args.Password)
+		case opCreditToDeleted:
+			return d.MemoryStore.CreditToDeleted(args.UserID)
+		}
+		return d.MemoryStore.DeleteUser(args.UserID)
+	case opRevokeSessions, opEndSession:
+		args := &sessionArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		if record.Op == opRevokeSessions {
+			return d.MemoryStore.RevokeSessions(args.UserID, args.Before)
+		}
+		return d.MemoryStore.EndSession(args.UserID, args.TokenID, args.ExpiresAt, args.Now)
+	case opCreateSubreddit:
+		subreddit := &models.Subreddit{}
+		if err := json.Unmarshal(record.Data, subreddit); err != nil {
+			return err
+		}
+		return d.MemoryStore.CreateSubreddit(subreddit)
+	case opJoinSubreddit, opLeaveSubreddit:
+		args := &membershipArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		if record.Op == opJoinSubreddit {
+			return d.MemoryStore.JoinSubreddit(args.SubredditID, args.UserID)
+		}
+		return d.MemoryStore.LeaveSubreddit(args.SubredditID, args.UserID)
+	case opSetSubscribed:
+		args := &membershipArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.MemoryStore.SetSubscribed(args.UserID, args.SubredditID, args.Joined)
+	case opCreatePost:
+		post := &models.Post{}
+		if err := json.Unmarshal(record.Data, post); err != nil {
+			return err
+		}
+		return d.MemoryStore.CreatePost(post)
+	case opAddComment:
+		comment := &models.Comment{}
+		if err := json.Unmarshal(record.Data, comment); err != nil {
+			return err
+		}
+		return d.MemoryStore.AddComment(comment)
+	case opSendMessage:
+		message := &models.DirectMessage{}
+		if err := json.Unmarshal(record.Data, message); err != nil {
+			return err
+		}
+		return d.MemoryStore.SendMessage(message)
+	case opVote:
+		args := &voteArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.replayVote(args, func() error {
+			return d.MemoryStore.Vote(args.TargetID, args.UserID, args.IsUpvote)
+		})
+	case opClearVote:
+		args := &voteArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.replayVote(args, func() error {
+			return d.MemoryStore.ClearVote(args.TargetID, args.UserID)
+		})
+	case opAddKarma:
+		args := &karmaArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.MemoryStore.AddKarma(args.UserID, args.PostKarma, args.CommentKarma)
+	case opAddModerator:
+		args := &membershipArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.MemoryStore.AddModerator(args.SubredditID, args.UserID)
+	case opSetBanned:
+		args := &banArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.MemoryStore.SetBanned(args.SubredditID, args.UserID, args.Banned)
+	case opRemovePost, opRemoveComment, opSetPostLocked:
+		args := &contentArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		switch record.Op {
+		case opRemovePost:
+			return d.MemoryStore.RemovePost(args.ID)
+		case opRemoveComment:
+			return d.MemoryStore.RemoveComment(args.ID)
+		}
+		return d.MemoryStore.SetPostLocked(args.ID, args.Locked)
+	case opEditPost, opEditComment:
+		args := &editArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		if record.Op == opEditPost {
+			return d.MemoryStore.EditPost(args.ID, args.Content, args.Edited)
+		}
+		return d.MemoryStore.EditComment(args.ID, args.Content, args.Edited)
+	case opDeletePost, opDeleteComment:
+		args := &contentArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		if record.Op == opDeletePost {
+			return d.MemoryStore.DeletePost(args.ID)
+		}
+		return d.MemoryStore.DeleteComment(args.ID)
+	case opAddModAction:
+		action := &models.ModAction{}
+		if err := json.Unmarshal(record.Data, action); err != nil {
+			return err
+		}
+		return d.MemoryStore.AddModAction(action)
+	case opMarkRead:
+		args := &readArgs{}
+		if err := json.Unmarshal(record.Data, args); err != nil {
+			return err
+		}
+		return d.MemoryStore.MarkRead(args.UserID, args.MessageIDs)
+	default:
+		return fmt.Errorf("%w %q", errUnknownOperation, record.Op)
+	}
+}
+
+// Logged write operations
+func (d *DurableStore) CreateUser(user *models.User) error {
+	return d.write(opCreateUser, user)
+}
+
+func (d *DurableStore) UpdateProfile(userID, displayName, bio string) error {
+	return d.write(opUpdateProfile, &userArgs{UserID: userID, DisplayName: displayName, Bio: bio})
+}
+
+func (d *DurableStore) SetPassword(userID, hash string) error {
+	return d.write(opSetPassword, &userArgs{UserID: userID, Password: hash})
+}
+
+func (d *DurableStore) DeleteUser(userID string) error {
+	return d.write(opDeleteUser, &userArgs{UserID: userID})
+}
+
+func (d *DurableStore) CreditToDeleted(userID string) error {
+	return d.write(opCreditToDeleted, &userArgs{UserID: userID})
+}
+
+func (d *DurableStore) RevokeSessions(userID string, before int64) error {
+	return d.write(opRevokeSessions, &sessionArgs{UserID: userID, Before: before})
+}
+
+func (d *DurableStore) EndSession(userID, tokenID string, expiresAt, now int64) error {
+	return d.write(opEndSession, &sessionArgs{UserID: userID, TokenID: tokenID, ExpiresAt: expiresAt, Now: now})
+}
+
+func (d *DurableStore) CreateSubreddit(subreddit *models.Subreddit) error {
+	return d.write(opCreateSubreddit, subreddit)
+}
+
+func (d *DurableStore) JoinSubreddit(subredditID, userID string) error {
+	return d.write(opJoinSubreddit, &membershipArgs{SubredditID: subredditID, UserID: userID})
+}
+
+func (d *DurableStore) LeaveSubreddit(subredditID, userID string) error {
+	return d.write(opLeaveSubreddit, &membershipArgs{SubredditID: subredditID, UserID: userID})
+}
+
+func (d *DurableStore) SetSubscribed(userID, subredditID string, joined bool) error {
+	return d.write(opSetSubscribed, &membershipArgs{SubredditID: subredditID, UserID: userID, Joined: joined})
+}
+
+func (d *DurableStore) CreatePost(post *models.Post) error {
+	return d.write(opCreatePost, post)
+}
+
+func (d *DurableStore) AddComment(comment *models.Comment) error {
+	return d.write(opAddComment, comment)
+}
+
+func (d *DurableStore) EditPost(id, content string, edited int64) error {
+	return d.write(opEditPost, &editArgs{ID: id, Content: content, Edited: edited})
+}
+
+func (d *DurableStore) DeletePost(id string) error {
+	return d.write(opDeletePost, &contentArgs{ID: id})
+}
+
+func (d *DurableStore) EditComment(id, content string, edited int64) error {
+	return d.write(opEditComment, &editArgs{ID: id, Content: content, Edited: edited})
+}
+
+func (d *DurableStore) DeleteComment(id string) error {
+	return d.write(opDeleteComment, &contentArgs{ID: id})
+}
+
+func (d *DurableStore) SendMessage(message *models.DirectMessage) error {
+	return d.write(opSendMessage, message)
+}
+
+func (d *DurableStore) MarkRead(userID string, messageIDs []string) error {
+	return d.write(opMarkRead, &readArgs{UserID: userID, MessageIDs: messageIDs})
+}
+
+func (d *DurableStore) Vote(targetID, userID string, isUpvote bool) error {
+	return d.write(opVote, &voteArgs{TargetID: targetID, UserID: userID, IsUpvote: isUpvote, TallyOnly: true})
+}
+
+func (d *DurableStore) ClearVote(targetID, userID string) error {
+	return d.write(opClearVote, &voteArgs{TargetID: targetID, UserID: userID, TallyOnly: true})
+}
+
+func (d *DurableStore) AddKarma(userID string, postDelta, commentDelta int32) error {
+	return d.write(opAddKarma, &karmaArgs{UserID: userID, PostKarma: postDelta, CommentKarma: commentDelta})
+}
+
+// replayVote applies a logged vote with apply. A vote logged without
+// TallyOnly also changed the karma of the target's author, if stored here.
+func (d *DurableStore) replayVote(args *voteArgs, apply func() error) error {
+	if args.TallyOnly {
+		return apply()
+	}
+	authorID, before, isPost := d.MemoryStore.voteTarget(args.TargetID)
+	if err := apply(); err != nil {
+		return err
+	}
+	_, after, _ := d.MemoryStore.voteTarget(args.TargetID)
+	if after == before {
+		return nil
+	}
+	var err error
+	if isPost {
+		err = d.MemoryStore.AddKarma(authorID, after-before, 0)
+	} else {
+		err = d.MemoryStore.AddKarma(authorID, 0, after-before)
+	}
+	var notFound *store.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+func (d *DurableStore) AddModerator(subredditID, userID string) error {
+	return d.write(opAddModerator, &membershipArgs{SubredditID: subredditID, UserID: userID})
+}
+
+func (d *DurableStore) SetBanned(subredditID, userID string, banned bool) error {
+	return d.write(opSetBanned, &banArgs{SubredditID: subredditID, UserID: userID, Banned: banned})
+}
+
+func (d *DurableStore) RemovePost(id string) error {
+	return d.write(opRemovePost, &contentArgs{ID: id})
+}
+
+func (d *DurableStore) RemoveComment(id string) error {
+	return d.write(opRemoveComment, &contentArgs{ID: id})
+}
+
+func (d *DurableStore) SetPostLocked(id string, locked bool) error {
+	return d.write(opSetPostLocked, &contentArgs{ID: id, Locked: locked})
+}
+
+func (d *DurableStore) AddModAction(action *models.ModAction) error {
+	return d.write(opAddModAction, action)
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func syncDir(dir string) error {
+	handle, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer handle.Close()
+	return handle.Sync()
+}
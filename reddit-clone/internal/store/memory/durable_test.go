@@ -0,0 +1,294 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+	"reddit-clone/internal/store/storetest"
+)
+
+// testOps is a sequence of writes touching every logged operation,
+// including ones that fail and must replay as no-ops.
+var testOps = []func(s store.Store) error{
+	func(s store.Store) error { return s.CreateUser(&models.User{ID: "u1", Username: "alice", Created: 1}) },
+	func(s store.Store) error { return s.CreateUser(&models.User{ID: "u2", Username: "bob", Created: 2}) },
+	func(s store.Store) error { return s.CreateUser(&models.User{ID: "u1", Username: "duplicate"}) },
+	func(s store.Store) error {
+		return s.CreateSubreddit(&models.Subreddit{ID: "s1", Name: "golang", CreatorID: "u1", Members: map[string]bool{}, Created: 3})
+	},
+	func(s store.Store) error { return s.JoinSubreddit("s1", "u1") },
+	func(s store.Store) error { return s.JoinSubreddit("s1", "u2") },
+	func(s store.Store) error {
+		return s.CreatePost(&models.Post{ID: "p1", SubredditID: "s1", AuthorID: "u1", Title: "Hello", Created: 4})
+	},
+	func(s store.Store) error { return s.Vote("p1", "u2", true) },
+	func(s store.Store) error { return s.Vote("p1", "u1", false) },
+	func(s store.Store) error {
+		return s.AddComment(&models.Comment{ID: "c1", PostID: "p1", AuthorID: "u2", Content: "Hi", Created: 5})
+	},
+	func(s store.Store) error {
+		return s.AddComment(&models.Comment{ID: "c2", PostID: "p1", ParentID: "c1", AuthorID: "u1", Content: "Hey", Created: 6})
+	},
+	func(s store.Store) error {
+		return s.SendMessage(&models.DirectMessage{ID: "m1", FromID: "u1", ToID: "u2", Content: "ping", Timestamp: 7})
+	},
+	func(s store.Store) error { return s.LeaveSubreddit("s1", "u2") },
+	func(s store.Store) error { return s.JoinSubreddit("missing", "u2") },
+	func(s store.Store) error { return s.SetSubscribed("u2", "remote", true) },
+	func(s store.Store) error { return s.SetSubscribed("u1", "elsewhere", true) },
+	func(s store.Store) error { return s.SetSubscribed("u1", "elsewhere", false) },
+	func(s store.Store) error { return s.Vote("p1", "u2", true) },
+	func(s store.Store) error { return s.Vote("c1", "u1", true) },
+	func(s store.Store) error { return s.ClearVote("p1", "u1") },
+	func(s store.Store) error { return s.AddKarma("u1", 1, 0) },
+	func(s store.Store) error { return s.AddKarma("u2", 0, 1) },
+	func(s store.Store) error { return s.AddKarma("missing", 1, 0) },
+	func(s store.Store) error { return s.EditPost("p1", "Hello, world", 8) },
+	func(s store.Store) error { return s.EditComment("c1", "Hi!", 9) },
+	func(s store.Store) error { return s.DeleteComment("c2") },
+	func(s store.Store) error { return s.DeletePost("missing") },
+	func(s store.Store) error { return s.UpdateProfile("u2", "Bob", "Hi there") },
+	func(s store.Store) error { return s.SetPassword("u2", "hash") },
+	func(s store.Store) error { return s.RevokeSessions("u2", 42) },
+	func(s store.Store) error { return s.EndSession("u2", "t1", 100, 50) },
+	func(s store.Store) error { return s.EndSession("u2", "t2", 200, 150) },
+	func(s store.Store) error { return s.DeleteUser("u1") },
+	func(s store.Store) error { return s.DeleteUser("missing") },
+	func(s store.Store) error { return s.CreditToDeleted("u2") },
+}
+
+func openDurable(t *testing.T, dir string, opts DurableOptions) *DurableStore {
+	t.Helper()
+	d, err := OpenDurableStore(dir, opts)
+	if err != nil {
+		t.Fatalf("Failed to open durable store: %v", err)
+	}
+	return d
+}
+
+// expectedState returns the snapshot encoding of a plain MemoryStore after
+// applying the first n operations.
+func expectedState(t *testing.T, n int) []byte {
+	t.Helper()
+	reference := NewMemoryStore()
+	for _, op := range testOps[:n] {
+		op(reference)
+	}
+	return encodeState(t, reference)
+}
+
+func encodeState(t *testing.T, m *MemoryStore) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := m.writeSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to encode store: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func copyDir(t *testing.T, src, dst string) {
+	t.Helper()
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", src, err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(src, entry.Name()))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", entry.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dst, entry.Name()), data, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", entry.Name(), err)
+		}
+	}
+}
+
+func TestDurableStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		d := openDurable(t, t.TempDir(), DurableOptions{SnapshotEvery: 3})
+		t.Cleanup(func() { d.Close() })
+		return d
+	})
+}
+
+func TestDurableStoreRecoversAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable(t, dir, DurableOptions{SnapshotEvery: 4, SyncWrites: true})
+	for _, op := range testOps {
+		op(d)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Failed to close durable store: %v", err)
+	}
+
+	reopened := openDurable(t, dir, DurableOptions{})
+	defer reopened.Close()
+	if got, want := encodeState(t, reopened.MemoryStore), expectedState(t, len(testOps)); !bytes.Equal(got, want) {
+		t.Errorf("Recovered state differs from expected state:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+// TestDurableStoreCrashRecovery simulates a crash at every byte of the log,
+// with and without a snapshot in front of it, and checks that recovery yields
+// exactly the writes whose records were complete.
+func TestDurableStoreCrashRecovery(t *testing.T) {
+	for _, snapshotAt := range []int{0, 5} {
+		t.Run(fmt.Sprintf("snapshot_after_%d", snapshotAt), func(t *testing.T) {
+			dir := t.TempDir()
+			d := openDurable(t, dir, DurableOptions{})
+			logPath := filepath.Join(dir, logFile)
+
+			// offsets[i] is the log size once operation i has been logged.
+			offsets := map[int64]int{0: snapshotAt}
+			var sizes []int64
+			for i, op := range testOps {
+				op(d)
+				if i+1 == snapshotAt {
+					if err := d.Snapshot(); err != nil {
+						t.Fatalf("Failed to snapshot: %v", err)
+					}
+					continue
+				}
+				if i+1 > snapshotAt {
+					info, err := os.Stat(logPath)
+					if err != nil {
+						t.Fatalf("Failed to stat log: %v", err)
+					}
+					offsets[info.Size()] = i + 1
+					sizes = append(sizes, info.Size())
+				}
+			}
+			// Abandon the store without Close, as a crash would.
+			d.log.close()
+
+			fullSize := sizes[len(sizes)-1]
+			applied := snapshotAt
+			for cut := int64(0); cut <= fullSize; cut++ {
+				if n, ok := offsets[cut]; ok {
+					applied = n
+				}
+
+				crashed := t.TempDir()
+				copyDir(t, dir, crashed)
+				if err := os.Truncate(filepath.Join(crashed, logFile), cut); err != nil {
+					t.Fatalf("Failed to truncate log: %v", err)
+				}
+
+				recovered := openDurable(t, crashed, DurableOptions{})
+				if got, want := encodeState(t, recovered.MemoryStore), expectedState(t, applied); !bytes.Equal(got, want) {
+					t.Fatalf("Cut at byte %d: expected the first %d writes\n got: %s\nwant: %s", cut, applied, got, want)
+				}
+
+				// The torn tail must be gone so new writes land after the
+				// last intact record.
+				if err := recovered.CreateUser(&models.User{ID: "after-crash"}); err != nil {
+					t.Fatalf("Cut at byte %d: failed to write after recovery: %v", cut, err)
+				}
+				recovered.log.close()
+				again := openDurable(t, crashed, DurableOptions{})
+				if _, err := again.GetUser("after-crash"); err != nil {
+					t.Fatalf("Cut at byte %d: write after recovery was lost: %v", cut, err)
+				}
+				again.log.close()
+			}
+		})
+	}
+}
+
+// TestDurableStoreSkipsRecordsCoveredBySnapshot simulates a crash after a
+// snapshot was written but before the log was compacted.
+func TestDurableStoreSkipsRecordsCoveredBySnapshot(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable(t, dir, DurableOptions{})
+	for _, op := range testOps {
+		op(d)
+	}
+
+	logPath := filepath.Join(dir, logFile)
+	staleLog, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	if err := d.Snapshot(); err != nil {
+		t.Fatalf("Failed to snapshot: %v", err)
+	}
+	d.log.close()
+	if err := os.WriteFile(logPath, staleLog, 0600); err != nil {
+		t.Fatalf("Failed to restore stale log: %v", err)
+	}
+
+	recovered := openDurable(t, dir, DurableOptions{})
+	defer recovered.Close()
+	if got, want := encodeState(t, recovered.MemoryStore), expectedState(t, len(testOps)); !bytes.Equal(got, want) {
+		t.Errorf("Records were applied twice:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDurableStoreCompactsLog(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable(t, dir, DurableOptions{SnapshotEvery: len(testOps)})
+	defer d.Close()
+
+	for _, op := range testOps {
+		op(d)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, logFile))
+	if err != nil {
+		t.Fatalf("Failed to stat log: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("Expected the log to be compacted after a snapshot, got %d bytes", info.Size())
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile)); err != nil {
+		t.Errorf("Expected a snapshot file: %v", err)
+	}
+}
+
+func TestDurableStoreRebuildsActivityFromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable(t, dir, DurableOptions{})
+	for _, op := range testOps {
+		op(d)
+	}
+	wantRecent, wantLast, err := d.GetSubredditActivity("s1", 0)
+	if err != nil || wantRecent == 0 {
+		t.Fatalf("Expected activity in s1 before the snapshot, got %d (%v)", wantRecent, err)
+	}
+	if err := d.Snapshot(); err != nil {
+		t.Fatalf("Failed to snapshot: %v", err)
+	}
+	d.Close()
+
+	recovered := openDurable(t, dir, DurableOptions{})
+	defer recovered.Close()
+	recent, last, err := recovered.GetSubredditActivity("s1", 0)
+	if err != nil || recent != wantRecent || last != wantLast {
+		t.Errorf("Expected activity %d, %d after loading the snapshot, got %d, %d (%v)", wantRecent, wantLast, recent, last, err)
+	}
+}
+
+func TestDurableStoreReplaysVotesLoggedWithKarma(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable(t, dir, DurableOptions{})
+	for _, op := range testOps[:7] {
+		op(d)
+	}
+	// Votes used to credit the author's karma themselves, without AddKarma.
+	legacy := map[string]interface{}{"target_id": "p1", "user_id": "u2", "is_upvote": true}
+	if err := d.write(opVote, legacy); err != nil {
+		t.Fatalf("Failed to log a vote: %v", err)
+	}
+	d.Close()
+
+	recovered := openDurable(t, dir, DurableOptions{})
+	defer recovered.Close()
+	if user, err := recovered.GetUser("u1"); err != nil || user.PostKarma != 1 || user.Karma != 1 {
+		t.Errorf("Expected the replayed vote to credit u1 with 1 post karma, got %+v (%v)", user, err)
+	}
+}
@@ -0,0 +1,245 @@
+// store/memory/index.go
+package memory
+
+import (
+	"sort"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// Secondary indexes let listings touch only the items they return instead of
+// scanning every post or comment. Post and comment lists are kept in
+// store.PostBefore / store.CommentBefore order, so reads need no sorting.
+// All index methods expect m.mu to be held for writing.
+
+func (m *MemoryStore) indexPost(post *models.Post) {
+	m.subredditPosts[post.SubredditID] = insertSorted(m.subredditPosts[post.SubredditID], post, store.PostBefore)
+	m.authorPosts[post.AuthorID] = insertSorted(m.authorPosts[post.AuthorID], post, store.PostBefore)
+	if store.LivePost(post) {
+		m.setActivity(post.SubredditID, postActivity(post), true)
+	}
+}
+
+func (m *MemoryStore) indexComment(comment *models.Comment) {
+	m.postComments[comment.PostID] = insertSorted(m.postComments[comment.PostID], comment, store.CommentBefore)
+	m.authorComments[comment.AuthorID] = insertSorted(m.authorComments[comment.AuthorID], comment, store.CommentBefore)
+	if post, exists := m.posts[comment.PostID]; exists && store.LivePost(post) && store.LiveComment(comment) {
+		m.setActivity(post.SubredditID, commentActivity(comment), true)
+	}
+}
+
+// replacePost swaps the stored version of a post for an updated copy. Posts
+// are never changed in place, so callers may keep what the store returned.
+func (m *MemoryStore) replacePost(post *models.Post) {
+	live := store.LivePost(post)
+	if old := m.posts[post.ID]; old != nil && store.LivePost(old) != live {
+		// The post's comments count only while it is live.
+		m.setActivity(post.SubredditID, postActivity(post), live)
+		for _, comment := range m.postComments[post.ID] {
+			if store.LiveComment(comment) {
+				m.setActivity(post.SubredditID, commentActivity(comment), live)
+			}
+		}
+	}
+	m.posts[post.ID] = post
+	replaceSorted(m.subredditPosts[post.SubredditID], post, store.PostBefore)
+	replaceSorted(m.authorPosts[post.AuthorID], post, store.PostBefore)
+}
+
+// replaceComment is replacePost for comments.
+func (m *MemoryStore) replaceComment(comment *models.Comment) {
+	live := store.LiveComment(comment)
+	old := m.comments[comment.ID]
+	if post := m.posts[comment.PostID]; old != nil && post != nil && store.LivePost(post) && store.LiveComment(old) != live {
+		m.setActivity(post.SubredditID, commentActivity(comment), live)
+	}
+	m.comments[comment.ID] = comment
+	replaceSorted(m.postComments[comment.PostID], comment, store.CommentBefore)
+	replaceSorted(m.authorComments[comment.AuthorID], comment, store.CommentBefore)
+}
+
+// activity is an entry of a subreddit's activity index, ordered by creation
+// time. Posts and comments are told apart by the prefix of key.
+type activity struct {
+	created int64
+	key     string
+}
+
+func postActivity(post *models.Post) activity {
+	return activity{created: post.Created, key: "post:" + post.ID}
+}
+
+func commentActivity(comment *models.Comment) activity {
+	return activity{created: comment.Created, key: "comment:" + comment.ID}
+}
+
+func activityBefore(a, b activity) bool {
+	if a.created != b.created {
+		return a.created < b.created
+	}
+	return a.key < b.key
+}
+
+// setActivity adds an entry to or removes it from a subreddit's activity.
+func (m *MemoryStore) setActivity(subredditID string, entry activity, live bool) {
+	if live {
+		m.activity[subredditID] = insertSorted(m.activity[subredditID], entry, activityBefore)
+		return
+	}
+	m.activity[subredditID] = removeSorted(m.activity[subredditID], entry, activityBefore)
+	if len(m.activity[subredditID]) == 0 {
+		delete(m.activity, subredditID)
+	}
+}
+
+func (m *MemoryStore) indexMessage(message *models.DirectMessage) {
+	m.sentMessages[message.FromID] = insertSorted(m.sentMessages[message.FromID], message, store.MessageBefore)
+	m.messageIDs[message.ID] = message
+}
+
+// replaceMessage is replacePost for direct messages.
+func (m *MemoryStore) replaceMessage(message *models.DirectMessage) {
+	replaceSorted(m.messages[message.ToID], message, store.MessageBefore)
+	replaceSorted(m.sentMessages[message.FromID], message, store.MessageBefore)
+	m.messageIDs[message.ID] = message
+}
+
+func (m *MemoryStore) subscribe(userID, subredditID string) {
+	if m.subscriptions[userID] == nil {
+		m.subscriptions[userID] = make(map[string]bool)
+	}
+	m.subscriptions[userID][subredditID] = true
+}
+
+func (m *MemoryStore) unsubscribe(userID, subredditID string) {
+	delete(m.subscriptions[userID], subredditID)
+	if len(m.subscriptions[userID]) == 0 {
+		delete(m.subscriptions, userID)
+	}
+}
+
+func (m *MemoryStore) indexVote(targetID, userID string, isUpvote bool) {
+	if m.userVotes[userID] == nil {
+		m.userVotes[userID] = make(map[string]bool)
+	}
+	m.userVotes[userID][targetID] = isUpvote
+}
+
+func (m *MemoryStore) unindexVote(targetID, userID string) {
+	delete(m.userVotes[userID], targetID)
+	if len(m.userVotes[userID]) == 0 {
+		delete(m.userVotes, userID)
+	}
+}
+
+// rebuildIndexes recomputes every index from the primary maps, e.g. after
+// loading a snapshot.
+func (m *MemoryStore) rebuildIndexes() {
+	m.subredditPosts = make(map[string][]*models.Post)
+	m.authorPosts = make(map[string][]*models.Post)
+	m.postComments = make(map[string][]*models.Comment)
+	m.authorComments = make(map[string][]*models.Comment)
+	m.subscriptions = make(map[string]map[string]bool)
+	m.userVotes = make(map[string]map[string]bool)
+	m.subredditNames = make(map[string]string)
+	m.sentMessages = make(map[string][]*models.DirectMessage)
+	m.messageIDs = make(map[string]*models.DirectMessage)
+	m.activity = make(map[string][]activity)
+
+	for _, post := range m.posts {
+		m.subredditPosts[post.SubredditID] = append(m.subredditPosts[post.SubredditID], post)
+		m.authorPosts[post.AuthorID] = append(m.authorPosts[post.AuthorID], post)
+	}
+	for _, posts := range m.subredditPosts {
+		store.SortPosts(posts)
+	}
+	for _, posts := range m.authorPosts {
+		store.SortPosts(posts)
+	}
+
+	for _, comment := range m.comments {
+		m.postComments[comment.PostID] = append(m.postComments[comment.PostID], comment)
+		m.authorComments[comment.AuthorID] = append(m.authorComments[comment.AuthorID], comment)
+	}
+	for _, comments := range m.postComments {
+		store.SortComments(comments)
+	}
+	for _, comments := range m.authorComments {
+		store.SortComments(comments)
+	}
+
+	for _, post := range m.posts {
+		if !store.LivePost(post) {
+			continue
+		}
+		m.activity[post.SubredditID] = append(m.activity[post.SubredditID], postActivity(post))
+		for _, comment := range m.postComments[post.ID] {
+			if store.LiveComment(comment) {
+				m.activity[post.SubredditID] = append(m.activity[post.SubredditID], commentActivity(comment))
+			}
+		}
+	}
+	for _, list := range m.activity {
+		sort.Slice(list, func(i, j int) bool { return activityBefore(list[i], list[j]) })
+	}
+
+	for _, inbox := range m.messages {
+		for _, message := range inbox {
+			m.sentMessages[message.FromID] = append(m.sentMessages[message.FromID], message)
+			m.messageIDs[message.ID] = message
+		}
+	}
+	for _, sent := range m.sentMessages {
+		store.SortMessages(sent)
+	}
+
+	for targetID, voters := range m.votes {
+		for userID, isUpvote := range voters {
+			m.indexVote(targetID, userID, isUpvote)
+		}
+	}
+
+	for subredditID, subreddit := range m.subreddits {
+		m.subredditNames[store.NameKey(subreddit.Name)] = subredditID
+		for userID := range subreddit.Members {
+			m.subscribe(userID, subredditID)
+		}
+	}
+}
+
+// insertSorted adds item to list, which is ordered by before. New content is
+// usually the newest, so this is an append in the common case.
+func insertSorted[T any](list []T, item T, before func(a, b T) bool) []T {
+	i := sort.Search(len(list), func(i int) bool { return before(item, list[i]) })
+	list = append(list, item)
+	if i < len(list)-1 {
+		copy(list[i+1:], list[i:])
+		list[i] = item
+	}
+	return list
+}
+
+// replaceSorted overwrites the entry of list that sorts equal to item. The
+// orders are total, so that is the stored version of the same item.
+func replaceSorted[T any](list []T, item T, before func(a, b T) bool) {
+	i := sort.Search(len(list), func(i int) bool { return !before(list[i], item) })
+	if i < len(list) && !before(item, list[i]) {
+		list[i] = item
+	}
+}
+
+// removeSorted drops the entry of list that sorts equal to item, if any.
+func removeSorted[T any](list []T, item T, before func(a, b T) bool) []T {
+	i := sort.Search(len(list), func(i int) bool { return !before(list[i], item) })
+	if i < len(list) && !before(item, list[i]) {
+		return append(list[:i], list[i+1:]...)
+	}
+	return list
+}
+
+// copyList copies an index entry so that callers can keep the result while
+// the store keeps inserting.
+func copyList[T any](list []T) []T {
+	return append(make([]T, 0, len(list)), list...)
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"reddit-clone/internal/models"
+)
+
+// benchmarkSizes is the total amount of content in the store. The subreddit
+// and post being read stay the same size, so per-read cost should not grow.
+var benchmarkSizes = []int{1_000, 10_000, 100_000, 1_000_000}
+
+const (
+	benchSubreddits = 1_000
+	benchListing    = 100
+)
+
+// fillStore creates total posts spread over benchSubreddits subreddits plus
+// benchListing posts in "target", and total comments spread over those posts
+// plus benchListing comments on "target-post".
+func fillStore(b *testing.B, total int) *MemoryStore {
+	b.Helper()
+	store := NewMemoryStore()
+	for i := 0; i < total; i++ {
+		store.CreatePost(&models.Post{
+			ID:          fmt.Sprintf("p%d", i),
+			SubredditID: fmt.Sprintf("s%d", i%benchSubreddits),
+			AuthorID:    fmt.Sprintf("u%d", i%benchSubreddits),
+			Created:     int64(i),
+		})
+		store.AddComment(&models.Comment{
+			ID:       fmt.Sprintf("c%d", i),
+			PostID:   fmt.Sprintf("p%d", i/2),
+			AuthorID: fmt.Sprintf("u%d", i%benchSubreddits),
+			Created:  int64(i),
+		})
+	}
+	for i := 0; i < benchListing; i++ {
+		store.CreatePost(&models.Post{ID: fmt.Sprintf("target-%d", i), SubredditID: "target", AuthorID: "target-author", Created: int64(i)})
+		store.AddComment(&models.Comment{ID: fmt.Sprintf("target-c%d", i), PostID: "target-post", AuthorID: "target-author", Created: int64(i)})
+	}
+	return store
+}
+
+func benchmarkBySize(b *testing.B, read func(b *testing.B, store *MemoryStore)) {
+	for _, total := range benchmarkSizes {
+		if testing.Short() && total > 100_000 {
+			continue
+		}
+		b.Run(fmt.Sprintf("total=%d", total), func(b *testing.B) {
+			store := fillStore(b, total)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				read(b, store)
+			}
+		})
+	}
+}
+
+func BenchmarkGetSubredditPosts(b *testing.B) {
+	benchmarkBySize(b, func(b *testing.B, store *MemoryStore) {
+		if posts, _ := store.GetSubredditPosts("target"); len(posts) != benchListing {
+			b.Fatalf("Expected %d posts, got %d", benchListing, len(posts))
+		}
+	})
+}
+
+func BenchmarkGetComments(b *testing.B) {
+	benchmarkBySize(b, func(b *testing.B, store *MemoryStore) {
+		if comments, _ := store.GetComments("target-post"); len(comments) != benchListing {
+			b.Fatalf("Expected %d comments, got %d", benchListing, len(comments))
+		}
+	})
+}
+
+func BenchmarkGetUserPosts(b *testing.B) {
+	benchmarkBySize(b, func(b *testing.B, store *MemoryStore) {
+		if posts, _ := store.GetUserPosts("target-author"); len(posts) != benchListing {
+			b.Fatalf("Expected %d posts, got %d", benchListing, len(posts))
+		}
+	})
+}
+
+func BenchmarkCreatePost(b *testing.B) {
+	store := NewMemoryStore()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		store.CreatePost(&models.Post{ID: fmt.Sprintf("p%d", i), SubredditID: "s1", AuthorID: "u1", Created: int64(i)})
+	}
+}
+
+func TestIndexesSurviveSnapshot(t *testing.T) {
+	store := NewMemoryStore()
+	store.CreateSubreddit(&models.Subreddit{ID: "s1", Name: "s1", Members: map[string]bool{}})
+	store.JoinSubreddit("s1", "u1")
+	store.CreatePost(&models.Post{ID: "p2", SubredditID: "s1", AuthorID: "u1", Created: 2})
+	store.CreatePost(&models.Post{ID: "p1", SubredditID: "s1", AuthorID: "u1", Created: 1})
+	store.AddComment(&models.Comment{ID: "c1", PostID: "p1", AuthorID: "u2", Created: 3})
+
+	var buf bytes.Buffer
+	if err := store.writeSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+	restored := NewMemoryStore()
+	if err := restored.loadSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+
+	posts, _ := restored.GetSubredditPosts("s1")
+	if len(posts) != 2 || posts[0].ID != "p1" {
+		t.Errorf("Expected the subreddit index to be rebuilt in order, got %d posts", len(posts))
+	}
+	if posts, _ := restored.GetUserPosts("u1"); len(posts) != 2 {
+		t.Errorf("Expected the author index to be rebuilt, got %d posts", len(posts))
+	}
+	if comments, _ := restored.GetComments("p1"); len(comments) != 1 {
+		t.Errorf("Expected the comment index to be rebuilt, got %d comments", len(comments))
+	}
+	if comments, _ := restored.GetUserComments("u2"); len(comments) != 1 {
+		t.Errorf("Expected the comment author index to be rebuilt, got %d comments", len(comments))
+	}
+	if subscriptions, _ := restored.GetUserSubreddits("u1"); len(subscriptions) != 1 {
+		t.Errorf("Expected the subscription index to be rebuilt, got %v", subscriptions)
+	}
+}
+
+func TestInsertSortedKeepsOrder(t *testing.T) {
+	var list []int
+	for _, v := range []int{5, 1, 4, 2, 3, 6} {
+		list = insertSorted(list, v, func(a, b int) bool { return a < b })
+	}
+	if got := fmt.Sprint(list); got != "[1 2 3 4 5 6]" {
+		t.Errorf("Expected [1 2 3 4 5 6], got %s", got)
+	}
+}
@@ -0,0 +1,83 @@
+// store/memory/snapshot.go
+package memory
+
+import (
+	"encoding/json"
+	"io"
+
+	"reddit-clone/internal/models"
+)
+
+// snapshotState is the serialisable form of everything held by a MemoryStore.
+type snapshotState struct {
+	Users      map[string]*models.User            `json:"users"`
+	Subreddits map[string]*models.Subreddit       `json:"subreddits"`
+	Posts      map[string]*models.Post            `json:"posts"`
+	Comments   map[string]*models.Comment         `json:"comments"`
+	Messages   map[string][]*models.DirectMessage `json:"messages"`
+	Votes      map[string]map[string]bool         `json:"votes"`
+	ModLog     map[string][]*models.ModAction     `json:"mod_log"`
+	// Subscriptions also holds those recorded with SetSubscribed, which
+	// cannot be derived from the subreddits.
+	Subscriptions map[string]map[string]bool `json:"subscriptions,omitempty"`
+}
+
+// writeSnapshot encodes the full store contents to w. Map keys are written in
+// sorted order, so equal stores always produce identical snapshots.
+func (m *MemoryStore) writeSnapshot(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(&snapshotState{
+		Users:      m.users,
+		Subreddits: m.subreddits,
+		Posts:      m.posts,
+		Comments:   m.comments,
+		Messages:   m.messages,
+		Votes:      m.votes,
+		ModLog:     m.modLog,
+
+		Subscriptions: m.subscriptions,
+	})
+}
+
+// loadSnapshot replaces the store contents with a snapshot written by
+// writeSnapshot.
+func (m *MemoryStore) loadSnapshot(r io.Reader) error {
+	state := &snapshotState{}
+	if err := json.NewDecoder(r).Decode(state); err != nil {
+		return err
+	}
+
+	fresh := NewMemoryStore()
+	copyInto(fresh.users, state.Users)
+	copyInto(fresh.subreddits, state.Subreddits)
+	copyInto(fresh.posts, state.Posts)
+	copyInto(fresh.comments, state.Comments)
+	copyInto(fresh.messages, state.Messages)
+	copyInto(fresh.votes, state.Votes)
+	copyInto(fresh.modLog, state.ModLog)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users = fresh.users
+	m.subreddits = fresh.subreddits
+	m.posts = fresh.posts
+	m.comments = fresh.comments
+	m.messages = fresh.messages
+	m.votes = fresh.votes
+	m.modLog = fresh.modLog
+	m.rebuildIndexes()
+	for userID, subredditIDs := range state.Subscriptions {
+		for subredditID := range subredditIDs {
+			m.subscribe(userID, subredditID)
+		}
+	}
+	return nil
+}
+
+func copyInto[V any](dst, src map[string]V) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
@@ -2,8 +2,10 @@
 package memory
 
 import (
-	"errors"
+	"fmt"
 	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+	"sort"
 	"sync"
 )
 
@@ -14,7 +16,21 @@ type MemoryStore struct {
 	comments   map[string]*models.Comment
 	messages   map[string][]*models.DirectMessage
 	votes      map[string]map[string]bool // targetID -> userID -> upvote/downvote
-	mu         sync.RWMutex
+	modLog     map[string][]*models.ModAction
+
+	// Secondary indexes, see index.go
+	subredditPosts map[string][]*models.Post
+	authorPosts    map[string][]*models.Post
+	postComments   map[string][]*models.Comment
+	authorComments map[string][]*models.Comment
+	subscriptions  map[string]map[string]bool // userID -> subredditID set
+	userVotes      map[string]map[string]bool // userID -> targetID -> upvote/downvote
+	subredditNames map[string]string          // store.NameKey(name) -> subredditID
+	sentMessages   map[string][]*models.DirectMessage
+	messageIDs     map[string]*models.DirectMessage
+	activity       map[string][]activity // subredditID -> live posts and comments
+
+	mu sync.RWMutex
 }
 
 func NewMemoryStore() *MemoryStore {
@@ -25,6 +41,18 @@ func NewMemoryStore() *MemoryStore {
 		comments:   make(map[string]*models.Comment),
 		messages:   make(map[string][]*models.DirectMessage),
 		votes:      make(map[string]map[string]bool),
+		modLog:     make(map[string][]*models.ModAction),
+
+		subredditPosts: make(map[string][]*models.Post),
+		authorPosts:    make(map[string][]*models.Post),
+		postComments:   make(map[string][]*models.Comment),
+		authorComments: make(map[string][]*models.Comment),
+		subscriptions:  make(map[string]map[string]bool),
+		userVotes:      make(map[string]map[string]bool),
+		subredditNames: make(map[string]string),
+		sentMessages:   make(map[string][]*models.DirectMessage),
+		activity:       make(map[string][]activity),
+		messageIDs:     make(map[string]*models.DirectMessage),
 	}
 }
 
@@ -34,7 +62,7 @@ func (m *MemoryStore) CreateUser(user *models.User) error {
 	defer m.mu.Unlock()
 
 	if _, exists := m.users[user.ID]; exists {
-		return errors.New("user already exists")
+		return fmt.Errorf("user %w", store.ErrAlreadyExists)
 	}
 
 	m.users[user.ID] = user
@@ -47,21 +75,104 @@ func (m *MemoryStore) GetUser(id string) (*models.User, error) {
 
 	user, exists := m.users[id]
 	if !exists {
-		return nil, errors.New("user not found")
+		return nil, &store.NotFoundError{Kind: "user", ID: id}
 	}
 	return user, nil
 }
 
+func (m *MemoryStore) UpdateProfile(userID, displayName, bio string) error {
+	return m.setUser(userID, func(user *models.User) {
+		user.DisplayName, user.Bio = displayName, bio
+	})
+}
+
+func (m *MemoryStore) SetPassword(userID, hash string) error {
+	return m.setUser(userID, func(user *models.User) { user.Password = hash })
+}
+
+func (m *MemoryStore) RevokeSessions(userID string, before int64) error {
+	return m.setUser(userID, func(user *models.User) { user.SessionsRevoked = before })
+}
+
+func (m *MemoryStore) EndSession(userID, tokenID string, expiresAt, now int64) error {
+	return m.setUser(userID, func(user *models.User) {
+		store.ApplyLogout(user, tokenID, expiresAt, now)
+	})
+}
+
+func (m *MemoryStore) DeleteUser(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.users[userID]; !exists {
+		return &store.NotFoundError{Kind: "user", ID: userID}
+	}
+	m.updateUser(userID, func(user *models.User) {
+		user.Username, user.Password, user.DisplayName, user.Bio = "", "", "", ""
+		user.Deleted, user.LoggedOut = true, nil
+	})
+	m.creditToDeleted(userID)
+	return nil
+}
+
+func (m *MemoryStore) CreditToDeleted(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.creditToDeleted(userID)
+	return nil
+}
+
+func (m *MemoryStore) creditToDeleted(userID string) {
+	for _, post := range m.authorPosts[userID] {
+		updated := *post
+		updated.AuthorID = models.DeletedAuthor
+		m.posts[post.ID] = &updated
+		replaceSorted(m.subredditPosts[post.SubredditID], &updated, store.PostBefore)
+		m.authorPosts[models.DeletedAuthor] = insertSorted(m.authorPosts[models.DeletedAuthor], &updated, store.PostBefore)
+	}
+	delete(m.authorPosts, userID)
+
+	for _, comment := range m.authorComments[userID] {
+		updated := *comment
+		updated.AuthorID = models.DeletedAuthor
+		m.comments[comment.ID] = &updated
+		replaceSorted(m.postComments[comment.PostID], &updated, store.CommentBefore)
+		m.authorComments[models.DeletedAuthor] = insertSorted(m.authorComments[models.DeletedAuthor], &updated, store.CommentBefore)
+	}
+	delete(m.authorComments, userID)
+}
+
+// setUser is updateUser for callers that do not hold m.mu and need to know
+// whether the user exists.
+func (m *MemoryStore) setUser(id string, update func(user *models.User)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.users[id]; !exists {
+		return &store.NotFoundError{Kind: "user", ID: id}
+	}
+	m.updateUser(id, update)
+	return nil
+}
+
 // Subreddit operations
 func (m *MemoryStore) CreateSubreddit(subreddit *models.Subreddit) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if _, exists := m.subreddits[subreddit.ID]; exists {
-		return errors.New("subreddit already exists")
+		return fmt.Errorf("subreddit %w", store.ErrAlreadyExists)
+	}
+	if _, exists := m.subredditNames[store.NameKey(subreddit.Name)]; exists {
+		return fmt.Errorf("subreddit name %w", store.ErrAlreadyExists)
 	}
 
-	m.subreddits[subreddit.ID] = subreddit
+	m.subreddits[subreddit.ID] = copySubreddit(subreddit)
+	m.subredditNames[store.NameKey(subreddit.Name)] = subreddit.ID
+	for userID := range subreddit.Members {
+		m.subscribe(userID, subreddit.ID)
+	}
 	return nil
 }
 
@@ -71,9 +182,50 @@ func (m *MemoryStore) GetSubreddit(id string) (*models.Subreddit, error) {
 
 	subreddit, exists := m.subreddits[id]
 	if !exists {
-		return nil, errors.New("subreddit not found")
+		return nil, &store.NotFoundError{Kind: "subreddit", ID: id}
+	}
+
+	return copySubreddit(subreddit), nil
+}
+
+func (m *MemoryStore) GetSubredditByName(name string) (*models.Subreddit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, exists := m.subredditNames[store.NameKey(name)]
+	if !exists {
+		return nil, &store.NotFoundError{Kind: "subreddit", ID: name}
 	}
-	return subreddit, nil
+	return copySubreddit(m.subreddits[id]), nil
+}
+
+func (m *MemoryStore) ListSubreddits() ([]*models.Subreddit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subreddits := make([]*models.Subreddit, 0, len(m.subreddits))
+	for _, subreddit := range m.subreddits {
+		subreddits = append(subreddits, copySubreddit(subreddit))
+	}
+	store.SortSubreddits(subreddits)
+	return subreddits, nil
+}
+
+// copySubreddit copies a subreddit along with its member, moderator and ban
+// sets, which are changed in place. Stored subreddits are never shared with
+// callers.
+func copySubreddit(subreddit *models.Subreddit) *models.Subreddit {
+	copied := *subreddit
+	copied.Members = copySet(subreddit.Members)
+	copied.Moderators = copySet(subreddit.Moderators)
+	copied.Banned = copySet(subreddit.Banned)
+	return &copied
+}
+
+func copySet(set map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(set))
+	copyInto(copied, set)
+	return copied
 }
 
 func (m *MemoryStore) JoinSubreddit(subredditID, userID string) error {
@@ -82,13 +234,14 @@ func (m *MemoryStore) JoinSubreddit(subredditID, userID string) error {
 
 	subreddit, exists := m.subreddits[subredditID]
 	if !exists {
-		return errors.New("subreddit not found")
+		return &store.NotFoundError{Kind: "subreddit", ID: subredditID}
 	}
 
 	if subreddit.Members == nil {
 		subreddit.Members = make(map[string]bool)
 	}
 	subreddit.Members[userID] = true
+	m.subscribe(userID, subredditID)
 	return nil
 }
 
@@ -98,10 +251,35 @@ func (m *MemoryStore) LeaveSubreddit(subredditID, userID string) error {
 
 	subreddit, exists := m.subreddits[subredditID]
 	if !exists {
-		return errors.New("subreddit not found")
+		return &store.NotFoundError{Kind: "subreddit", ID: subredditID}
 	}
 
 	delete(subreddit.Members, userID)
+	m.unsubscribe(userID, subredditID)
+	return nil
+}
+
+func (m *MemoryStore) GetUserSubreddits(userID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subredditIDs := make([]string, 0, len(m.subscriptions[userID]))
+	for subredditID := range m.subscriptions[userID] {
+		subredditIDs = append(subredditIDs, subredditID)
+	}
+	sort.Strings(subredditIDs)
+	return subredditIDs, nil
+}
+
+func (m *MemoryStore) SetSubscribed(userID, subredditID string, joined bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if joined {
+		m.subscribe(userID, subredditID)
+	} else {
+		m.unsubscribe(userID, subredditID)
+	}
 	return nil
 }
 
@@ -111,10 +289,11 @@ func (m *MemoryStore) CreatePost(post *models.Post) error {
 	defer m.mu.Unlock()
 
 	if _, exists := m.posts[post.ID]; exists {
-		return errors.New("post already exists")
+		return fmt.Errorf("post %w", store.ErrAlreadyExists)
 	}
 
 	m.posts[post.ID] = post
+	m.indexPost(post)
 	return nil
 }
 
@@ -124,22 +303,43 @@ func (m *MemoryStore) GetPost(id string) (*models.Post, error) {
 
 	post, exists := m.posts[id]
 	if !exists {
-		return nil, errors.New("post not found")
+		return nil, &store.NotFoundError{Kind: "post", ID: id}
 	}
 	return post, nil
 }
 
-func (m *MemoryStore) GetSubredditPosts(subredditID string) ([]*models.Post, error) {
+func (m *MemoryStore) GetSubredditActivity(subredditID string, since int64) (int, int64, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var posts []*models.Post
-	for _, post := range m.posts {
-		if post.SubredditID == subredditID {
-			posts = append(posts, post)
-		}
+	list := m.activity[subredditID]
+	if len(list) == 0 {
+		return 0, 0, nil
 	}
-	return posts, nil
+	first := sort.Search(len(list), func(i int) bool { return list[i].created >= since })
+	return len(list) - first, list[len(list)-1].created, nil
+}
+
+func (m *MemoryStore) GetSubredditPosts(subredditID string) ([]*models.Post, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.subredditPosts[subredditID]), nil
+}
+
+func (m *MemoryStore) GetUserPosts(authorID string) ([]*models.Post, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.authorPosts[authorID]), nil
+}
+
+func (m *MemoryStore) EditPost(id, content string, edited int64) error {
+	return m.updatePost(id, func(post *models.Post) { store.ApplyPostEdit(post, content, edited) })
+}
+
+func (m *MemoryStore) DeletePost(id string) error {
+	return m.updatePost(id, func(post *models.Post) { post.Deleted = true })
 }
 
 // Comment operations
@@ -148,24 +348,45 @@ func (m *MemoryStore) AddComment(comment *models.Comment) error {
 	defer m.mu.Unlock()
 
 	if _, exists := m.comments[comment.ID]; exists {
-		return errors.New("comment already exists")
+		return fmt.Errorf("comment %w", store.ErrAlreadyExists)
 	}
 
 	m.comments[comment.ID] = comment
+	m.indexComment(comment)
 	return nil
 }
 
-func (m *MemoryStore) GetComments(postID string) ([]*models.Comment, error) {
+func (m *MemoryStore) GetComment(id string) (*models.Comment, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var comments []*models.Comment
-	for _, comment := range m.comments {
-		if comment.PostID == postID {
-			comments = append(comments, comment)
-		}
+	comment, exists := m.comments[id]
+	if !exists {
+		return nil, &store.NotFoundError{Kind: "comment", ID: id}
 	}
-	return comments, nil
+	return comment, nil
+}
+
+func (m *MemoryStore) GetComments(postID string) ([]*models.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.postComments[postID]), nil
+}
+
+func (m *MemoryStore) GetUserComments(authorID string) ([]*models.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.authorComments[authorID]), nil
+}
+
+func (m *MemoryStore) EditComment(id, content string, edited int64) error {
+	return m.updateComment(id, func(comment *models.Comment) { store.ApplyCommentEdit(comment, content, edited) })
+}
+
+func (m *MemoryStore) DeleteComment(id string) error {
+	return m.updateComment(id, func(comment *models.Comment) { comment.Deleted = true })
 }
 
 // Message operations
@@ -173,10 +394,12 @@ func (m *MemoryStore) SendMessage(message *models.DirectMessage) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.messages[message.ToID] == nil {
-		m.messages[message.ToID] = make([]*models.DirectMessage, 0)
+	if _, exists := m.messageIDs[message.ID]; exists {
+		return fmt.Errorf("message %w", store.ErrAlreadyExists)
 	}
-	m.messages[message.ToID] = append(m.messages[message.ToID], message)
+
+	m.messages[message.ToID] = insertSorted(m.messages[message.ToID], message, store.MessageBefore)
+	m.indexMessage(message)
 	return nil
 }
 
@@ -190,10 +413,62 @@ func (m *MemoryStore) GetMessages(userID string) ([]*models.DirectMessage, error
 		return make([]*models.DirectMessage, 0), nil // Return empty slice if no messages exist
 	}
 
-	return messages, nil
+	// Copy so callers can page through the inbox while new messages arrive
+	return copyList(messages), nil
+}
+
+func (m *MemoryStore) GetSentMessages(userID string) ([]*models.DirectMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.sentMessages[userID]), nil
+}
+
+func (m *MemoryStore) GetConversation(userID, otherID string) ([]*models.DirectMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	conversation := make([]*models.DirectMessage, 0)
+	for _, message := range m.messages[userID] {
+		if message.FromID == otherID {
+			conversation = append(conversation, message)
+		}
+	}
+	// Messages to oneself are already in the inbox.
+	if userID != otherID {
+		for _, message := range m.sentMessages[userID] {
+			if message.ToID == otherID {
+				conversation = append(conversation, message)
+			}
+		}
+	}
+	store.SortMessages(conversation)
+	return conversation, nil
+}
+
+func (m *MemoryStore) MarkRead(userID string, messageIDs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range messageIDs {
+		if message, exists := m.messageIDs[id]; !exists || message.ToID != userID {
+			return &store.NotFoundError{Kind: "message", ID: id}
+		}
+	}
+	for _, id := range messageIDs {
+		if message := m.messageIDs[id]; !message.Read {
+			read := *message
+			read.Read = true
+			m.replaceMessage(&read)
+		}
+	}
+	return nil
 }
 
 // Vote operations
+
+// Vote records a user's vote on a post or comment. Each user holds at most one
+// vote per target: repeating it is a no-op and switching direction replaces it.
 func (m *MemoryStore) Vote(targetID, userID string, isUpvote bool) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -202,16 +477,196 @@ func (m *MemoryStore) Vote(targetID, userID string, isUpvote bool) error {
 		m.votes[targetID] = make(map[string]bool)
 	}
 
+	previous, voted := m.votes[targetID][userID]
+	if voted && previous == isUpvote {
+		return nil
+	}
+	if voted {
+		m.applyVote(targetID, previous, -1)
+	}
+
 	m.votes[targetID][userID] = isUpvote
+	m.indexVote(targetID, userID, isUpvote)
+	m.applyVote(targetID, isUpvote, 1)
+	return nil
+}
+
+// ClearVote retracts a user's vote. Clearing a vote that was never cast is a no-op.
+func (m *MemoryStore) ClearVote(targetID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, voted := m.votes[targetID][userID]
+	if !voted {
+		return nil
+	}
+
+	delete(m.votes[targetID], userID)
+	if len(m.votes[targetID]) == 0 {
+		delete(m.votes, targetID)
+	}
+	m.unindexVote(targetID, userID)
+	m.applyVote(targetID, previous, -1)
+	return nil
+}
+
+func (m *MemoryStore) GetUserVotes(userID string) (map[string]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copySet(m.userVotes[userID]), nil
+}
+
+// applyVote adds (sign 1) or removes (sign -1) one vote from the tally of the
+// target (post or comment).
+func (m *MemoryStore) applyVote(targetID string, isUpvote bool, sign int32) {
+	delta := sign
+	if !isUpvote {
+		delta = -sign
+	}
 
-	// Update karma for the target (post or comment)
 	if post, exists := m.posts[targetID]; exists {
+		updated := *post
 		if isUpvote {
-			post.Karma++
+			updated.Ups += sign
 		} else {
-			post.Karma--
+			updated.Downs += sign
 		}
+		updated.Karma += delta
+		m.replacePost(&updated)
+	} else if comment, exists := m.comments[targetID]; exists {
+		updated := *comment
+		if isUpvote {
+			updated.Ups += sign
+		} else {
+			updated.Downs += sign
+		}
+		updated.Karma += delta
+		m.replaceComment(&updated)
 	}
+}
 
+func (m *MemoryStore) AddKarma(userID string, postDelta, commentDelta int32) error {
+	return m.setUser(userID, func(user *models.User) {
+		user.PostKarma += postDelta
+		user.CommentKarma += commentDelta
+		user.Karma += postDelta + commentDelta
+	})
+}
+
+// voteTarget returns the author and karma of the post or comment targetID,
+// and whether it is a post.
+func (m *MemoryStore) voteTarget(targetID string) (authorID string, karma int32, isPost bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if post, exists := m.posts[targetID]; exists {
+		return post.AuthorID, post.Karma, true
+	}
+	if comment, exists := m.comments[targetID]; exists {
+		return comment.AuthorID, comment.Karma, false
+	}
+	return "", 0, false
+}
+
+// updateUser stores an updated copy of a user, leaving the version earlier
+// readers hold untouched.
+func (m *MemoryStore) updateUser(id string, update func(user *models.User)) {
+	user, exists := m.users[id]
+	if !exists {
+		return
+	}
+	updated := *user
+	update(&updated)
+	m.users[id] = &updated
+}
+
+// Moderation operations
+func (m *MemoryStore) AddModerator(subredditID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subreddit, exists := m.subreddits[subredditID]
+	if !exists {
+		return &store.NotFoundError{Kind: "subreddit", ID: subredditID}
+	}
+	if subreddit.Moderators == nil {
+		subreddit.Moderators = make(map[string]bool)
+	}
+	subreddit.Moderators[userID] = true
+	return nil
+}
+
+func (m *MemoryStore) SetBanned(subredditID, userID string, banned bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subreddit, exists := m.subreddits[subredditID]
+	if !exists {
+		return &store.NotFoundError{Kind: "subreddit", ID: subredditID}
+	}
+	if !banned {
+		delete(subreddit.Banned, userID)
+		return nil
+	}
+	if subreddit.Banned == nil {
+		subreddit.Banned = make(map[string]bool)
+	}
+	subreddit.Banned[userID] = true
+	return nil
+}
+
+func (m *MemoryStore) RemovePost(id string) error {
+	return m.updatePost(id, func(post *models.Post) { post.Removed = true })
+}
+
+func (m *MemoryStore) SetPostLocked(id string, locked bool) error {
+	return m.updatePost(id, func(post *models.Post) { post.Locked = locked })
+}
+
+func (m *MemoryStore) updatePost(id string, update func(post *models.Post)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	post, exists := m.posts[id]
+	if !exists {
+		return &store.NotFoundError{Kind: "post", ID: id}
+	}
+	updated := *post
+	update(&updated)
+	m.replacePost(&updated)
 	return nil
 }
+
+func (m *MemoryStore) RemoveComment(id string) error {
+	return m.updateComment(id, func(comment *models.Comment) { comment.Removed = true })
+}
+
+func (m *MemoryStore) updateComment(id string, update func(comment *models.Comment)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment, exists := m.comments[id]
+	if !exists {
+		return &store.NotFoundError{Kind: "comment", ID: id}
+	}
+	updated := *comment
+	update(&updated)
+	m.replaceComment(&updated)
+	return nil
+}
+
+func (m *MemoryStore) AddModAction(action *models.ModAction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.modLog[action.SubredditID] = insertSorted(m.modLog[action.SubredditID], action, store.ModActionBefore)
+	return nil
+}
+
+func (m *MemoryStore) GetModLog(subredditID string) ([]*models.ModAction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return copyList(m.modLog[subredditID]), nil
+}
@@ -1,11 +1,21 @@
 package memory
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+	"reddit-clone/internal/store/storetest"
 )
 
+func TestMemoryStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		return NewMemoryStore()
+	})
+}
+
 func TestCreateAndGetUser(t *testing.T) {
 	store := NewMemoryStore()
 	user := &models.User{
@@ -53,3 +63,56 @@ func TestCreateAndGetPost(t *testing.T) {
 		t.Errorf("Expected post title 'Test Post', got '%s'", retrievedPost.Title)
 	}
 }
+
+func TestVoteScoresMatchVotesMap(t *testing.T) {
+	store := NewMemoryStore()
+	store.CreateUser(&models.User{ID: "author", Username: "author"})
+	store.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"})
+	store.AddComment(&models.Comment{ID: "c1", PostID: "post1", AuthorID: "author"})
+
+	var wg sync.WaitGroup
+	for v := 0; v < 32; v++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			userID := fmt.Sprintf("voter%d", v)
+			for r := 0; r < 100; r++ {
+				target := []string{"post1", "c1"}[r%2]
+				switch (v * r) % 3 {
+				case 0:
+					store.Vote(target, userID, true)
+				case 1:
+					store.Vote(target, userID, false)
+				case 2:
+					store.ClearVote(target, userID)
+				}
+			}
+		}(v)
+	}
+	wg.Wait()
+
+	tally := func(targetID string) int32 {
+		var score int32
+		for _, isUpvote := range store.votes[targetID] {
+			if isUpvote {
+				score++
+			} else {
+				score--
+			}
+		}
+		return score
+	}
+
+	post, _ := store.GetPost("post1")
+	if want := tally("post1"); post.Karma != want || post.Ups-post.Downs != want {
+		t.Errorf("Expected post karma %d, got %d (%d/%d)", want, post.Karma, post.Ups, post.Downs)
+	}
+	comments, _ := store.GetComments("post1")
+	if want := tally("c1"); comments[0].Karma != want {
+		t.Errorf("Expected comment karma %d, got %d", want, comments[0].Karma)
+	}
+	// The engine credits the author with AddKarma.
+	if author, _ := store.GetUser("author"); author.Karma != 0 {
+		t.Errorf("Expected votes to leave the author's karma alone, got %d", author.Karma)
+	}
+}
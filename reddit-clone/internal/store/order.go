@@ -0,0 +1,74 @@
+// store/order.go
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"reddit-clone/internal/models"
+)
+
+// The listing methods of Store return items oldest first with ties broken by
+// ID, so that repeated calls and paginated reads see the same order. These
+// helpers give implementations that order.
+
+func SubredditBefore(a, b *models.Subreddit) bool {
+	if a.Created != b.Created {
+		return a.Created < b.Created
+	}
+	return a.ID < b.ID
+}
+
+func PostBefore(a, b *models.Post) bool {
+	if a.Created != b.Created {
+		return a.Created < b.Created
+	}
+	return a.ID < b.ID
+}
+
+func CommentBefore(a, b *models.Comment) bool {
+	if a.Created != b.Created {
+		return a.Created < b.Created
+	}
+	return a.ID < b.ID
+}
+
+func MessageBefore(a, b *models.DirectMessage) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	return a.ID < b.ID
+}
+
+func ModActionBefore(a, b *models.ModAction) bool {
+	if a.Created != b.Created {
+		return a.Created < b.Created
+	}
+	return a.ID < b.ID
+}
+
+func SortSubreddits(subreddits []*models.Subreddit) {
+	sort.Slice(subreddits, func(i, j int) bool { return SubredditBefore(subreddits[i], subreddits[j]) })
+}
+
+func SortPosts(posts []*models.Post) {
+	sort.Slice(posts, func(i, j int) bool { return PostBefore(posts[i], posts[j]) })
+}
+
+func SortComments(comments []*models.Comment) {
+	sort.Slice(comments, func(i, j int) bool { return CommentBefore(comments[i], comments[j]) })
+}
+
+func SortMessages(messages []*models.DirectMessage) {
+	sort.Slice(messages, func(i, j int) bool { return MessageBefore(messages[i], messages[j]) })
+}
+
+func SortModActions(actions []*models.ModAction) {
+	sort.Slice(actions, func(i, j int) bool { return ModActionBefore(actions[i], actions[j]) })
+}
+
+// NameKey is the form of a subreddit name that uniqueness and lookups by name
+// compare.
+func NameKey(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,29 @@
+// store/session.go
+package store
+
+import "reddit-clone/internal/models"
+
+// ApplyLogout adds tokenID to the logged-out sessions of user and forgets
+// those that expired by now, which fail verification on their own. The map
+// is copied because it may be shared with a snapshot handed out earlier.
+func ApplyLogout(user *models.User, tokenID string, expiresAt, now int64) {
+	loggedOut := make(map[string]int64, len(user.LoggedOut)+1)
+	for id, expiry := range user.LoggedOut {
+		if expiry > now {
+			loggedOut[id] = expiry
+		}
+	}
+	loggedOut[tokenID] = expiresAt
+	user.LoggedOut = loggedOut
+}
+
+// SessionRevoked reports whether the session token with the given ID, issued
+// at issuedAt (unix nanoseconds), was revoked by deleting the account,
+// changing its password or logging out.
+func SessionRevoked(user *models.User, tokenID string, issuedAt int64) bool {
+	if user.Deleted || issuedAt < user.SessionsRevoked {
+		return true
+	}
+	_, loggedOut := user.LoggedOut[tokenID]
+	return loggedOut
+}
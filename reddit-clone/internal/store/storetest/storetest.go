@@ -0,0 +1,1083 @@
+// store/storetest/storetest.go
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"reddit-clone/internal/models"
+	"reddit-clone/internal/store"
+)
+
+// Run is the conformance suite every store.Store implementation must pass.
+// newStore is called once per subtest and must return an empty store.
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.Store)
+	}{
+		{"Users", testUsers},
+		{"NotFound", testNotFound},
+		{"AlreadyExists", testAlreadyExists},
+		{"Subreddits", testSubreddits},
+		{"Membership", testMembership},
+		{"Posts", testPosts},
+		{"Comments", testComments},
+		{"Messages", testMessages},
+		{"Conversations", testConversations},
+		{"Votes", testVotes},
+		{"VoteChanges", testVoteChanges},
+		{"ConcurrentVotes", testConcurrentVotes},
+		{"Ordering", testOrdering},
+		{"UserIndexes", testUserIndexes},
+		{"Snapshots", testSnapshots},
+		{"Moderation", testModeration},
+		{"Edits", testEdits},
+		{"Accounts", testAccounts},
+		{"Sessions", testSessions},
+		{"Activity", testActivity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+func testUsers(t *testing.T, s store.Store) {
+	user := &models.User{ID: "user1", Username: "testuser", Password: "password123", Created: 100}
+	if err := s.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := s.CreateUser(user); err == nil {
+		t.Error("Expected an error when creating a duplicate user")
+	}
+
+	got, err := s.GetUser("user1")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if got.Username != "testuser" || got.Created != 100 {
+		t.Errorf("Unexpected user returned: %+v", got)
+	}
+
+	if _, err := s.GetUser("missing"); err == nil {
+		t.Error("Expected an error for an unknown user")
+	}
+}
+
+func testNotFound(t *testing.T, s store.Store) {
+	lookups := map[string]func() error{
+		"user":      func() error { _, err := s.GetUser("missing"); return err },
+		"subreddit": func() error { _, err := s.GetSubreddit("missing"); return err },
+		"post":      func() error { _, err := s.GetPost("missing"); return err },
+		"comment":   func() error { _, err := s.GetComment("missing"); return err },
+		"message":   func() error { return s.MarkRead("user1", []string{"missing"}) },
+	}
+	for kind, lookup := range lookups {
+		var notFound *store.NotFoundError
+		err := lookup()
+		if !errors.As(err, &notFound) || notFound.Kind != kind || notFound.ID != "missing" {
+			t.Errorf("Expected a NotFoundError for the missing %s, got %v", kind, err)
+		}
+		if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Expected the missing %s to match ErrNotFound, got %v", kind, err)
+		}
+	}
+}
+
+func testAlreadyExists(t *testing.T, s store.Store) {
+	creates := map[string]func() error{
+		"user":      func() error { return s.CreateUser(&models.User{ID: "user1"}) },
+		"subreddit": func() error { return s.CreateSubreddit(&models.Subreddit{ID: "sub1", Name: "sub1"}) },
+		"name":      func() error { return s.CreateSubreddit(&models.Subreddit{ID: "sub2", Name: "SUB1"}) },
+		"post":      func() error { return s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1"}) },
+		"comment":   func() error { return s.AddComment(&models.Comment{ID: "c1", PostID: "post1"}) },
+		"message":   func() error { return s.SendMessage(&models.DirectMessage{ID: "m1", FromID: "user1", ToID: "user1"}) },
+	}
+	for _, kind := range []string{"user", "subreddit", "post", "comment", "message"} {
+		if err := creates[kind](); err != nil {
+			t.Fatalf("Failed to create %s: %v", kind, err)
+		}
+	}
+	for kind, create := range creates {
+		if err := create(); !errors.Is(err, store.ErrAlreadyExists) {
+			t.Errorf("Expected a duplicate %s to match ErrAlreadyExists, got %v", kind, err)
+		}
+	}
+}
+
+func testSubreddits(t *testing.T, s store.Store) {
+	subreddit := &models.Subreddit{ID: "sub1", Name: "golang", Description: "Gophers", CreatorID: "user1"}
+	if err := s.CreateSubreddit(subreddit); err != nil {
+		t.Fatalf("Failed to create subreddit: %v", err)
+	}
+	if err := s.CreateSubreddit(subreddit); err == nil {
+		t.Error("Expected an error when creating a duplicate subreddit")
+	}
+
+	got, err := s.GetSubreddit("sub1")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit: %v", err)
+	}
+	if got.Name != "golang" || got.Description != "Gophers" {
+		t.Errorf("Unexpected subreddit returned: %+v", got)
+	}
+
+	if _, err := s.GetSubreddit("missing"); err == nil {
+		t.Error("Expected an error for an unknown subreddit")
+	}
+
+	// Names are unique regardless of case and found the same way.
+	if err := s.CreateSubreddit(&models.Subreddit{ID: "sub2", Name: "GoLang"}); err == nil {
+		t.Error("Expected an error when creating a subreddit with a taken name")
+	}
+	if got, err := s.GetSubredditByName("GOLANG"); err != nil || got.ID != "sub1" {
+		t.Errorf("Expected sub1 by name, got %+v (%v)", got, err)
+	}
+	if _, err := s.GetSubredditByName("rust"); err == nil {
+		t.Error("Expected an error for an unknown name")
+	}
+
+	if err := s.CreateSubreddit(&models.Subreddit{ID: "sub0", Name: "rust", Created: -1}); err != nil {
+		t.Fatalf("Failed to create subreddit: %v", err)
+	}
+	list, err := s.ListSubreddits()
+	if err != nil {
+		t.Fatalf("Failed to list subreddits: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "sub0" || list[1].ID != "sub1" {
+		t.Errorf("Expected sub0 and sub1 oldest first, got %+v", list)
+	}
+}
+
+func testMembership(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+
+	if err := s.JoinSubreddit("sub1", "user1"); err != nil {
+		t.Fatalf("Failed to join subreddit: %v", err)
+	}
+	if err := s.JoinSubreddit("sub1", "user2"); err != nil {
+		t.Fatalf("Failed to join subreddit: %v", err)
+	}
+	if err := s.LeaveSubreddit("sub1", "user1"); err != nil {
+		t.Fatalf("Failed to leave subreddit: %v", err)
+	}
+
+	got, err := s.GetSubreddit("sub1")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit: %v", err)
+	}
+	if got.Members["user1"] || !got.Members["user2"] || len(got.Members) != 1 {
+		t.Errorf("Unexpected members after join/leave: %v", got.Members)
+	}
+
+	if err := s.JoinSubreddit("missing", "user1"); err == nil {
+		t.Error("Expected an error when joining an unknown subreddit")
+	}
+	if err := s.LeaveSubreddit("missing", "user1"); err == nil {
+		t.Error("Expected an error when leaving an unknown subreddit")
+	}
+}
+
+func testPosts(t *testing.T, s store.Store) {
+	post := &models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "user1", Title: "Test Post", Content: "Body", Created: 100}
+	if err := s.CreatePost(post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.CreatePost(post); err == nil {
+		t.Error("Expected an error when creating a duplicate post")
+	}
+	if err := s.CreatePost(&models.Post{ID: "post2", SubredditID: "sub2", AuthorID: "user1", Title: "Other"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	got, err := s.GetPost("post1")
+	if err != nil {
+		t.Fatalf("Failed to get post: %v", err)
+	}
+	if got.Title != "Test Post" || got.SubredditID != "sub1" {
+		t.Errorf("Unexpected post returned: %+v", got)
+	}
+	if _, err := s.GetPost("missing"); err == nil {
+		t.Error("Expected an error for an unknown post")
+	}
+
+	posts, err := s.GetSubredditPosts("sub1")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit posts: %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != "post1" {
+		t.Errorf("Expected only post1 in sub1, got %d posts", len(posts))
+	}
+
+	empty, err := s.GetSubredditPosts("no-posts")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit posts: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("Expected no posts, got %d", len(empty))
+	}
+}
+
+func testComments(t *testing.T, s store.Store) {
+	comments := []*models.Comment{
+		{ID: "c1", PostID: "post1", AuthorID: "user1", Content: "root", Created: 100},
+		{ID: "c2", PostID: "post1", ParentID: "c1", AuthorID: "user2", Content: "reply", Created: 101},
+		{ID: "c3", PostID: "post2", AuthorID: "user1", Content: "elsewhere", Created: 102},
+	}
+	for _, comment := range comments {
+		if err := s.AddComment(comment); err != nil {
+			t.Fatalf("Failed to add comment %s: %v", comment.ID, err)
+		}
+	}
+	if err := s.AddComment(comments[0]); err == nil {
+		t.Error("Expected an error when adding a duplicate comment")
+	}
+
+	comment, err := s.GetComment("c2")
+	if err != nil {
+		t.Fatalf("Failed to get comment: %v", err)
+	}
+	if comment.Content != "reply" || comment.PostID != "post1" {
+		t.Errorf("Unexpected comment returned: %+v", comment)
+	}
+	if _, err := s.GetComment("missing"); err == nil {
+		t.Error("Expected an error for an unknown comment")
+	}
+
+	got, err := s.GetComments("post1")
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 comments on post1, got %d", len(got))
+	}
+	for _, comment := range got {
+		if comment.ID == "c2" && comment.ParentID != "c1" {
+			t.Errorf("Expected c2 to keep parent c1, got '%s'", comment.ParentID)
+		}
+	}
+}
+
+func testMessages(t *testing.T, s store.Store) {
+	empty, err := s.GetMessages("user2")
+	if err != nil {
+		t.Fatalf("Failed to get messages: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected a non-nil empty inbox, got %v", empty)
+	}
+
+	for _, message := range []*models.DirectMessage{
+		{ID: "m1", FromID: "user1", ToID: "user2", Content: "first", Timestamp: 100},
+		{ID: "m2", FromID: "user3", ToID: "user2", Content: "second", Timestamp: 101, ReplyToID: "m1"},
+		{ID: "m3", FromID: "user2", ToID: "user1", Content: "other inbox", Timestamp: 102},
+	} {
+		if err := s.SendMessage(message); err != nil {
+			t.Fatalf("Failed to send message %s: %v", message.ID, err)
+		}
+	}
+
+	inbox, err := s.GetMessages("user2")
+	if err != nil {
+		t.Fatalf("Failed to get messages: %v", err)
+	}
+	if len(inbox) != 2 {
+		t.Fatalf("Expected 2 messages for user2, got %d", len(inbox))
+	}
+	if inbox[0].Content != "first" || inbox[1].ReplyToID != "m1" {
+		t.Errorf("Unexpected inbox contents: %+v, %+v", inbox[0], inbox[1])
+	}
+}
+
+func testConversations(t *testing.T, s store.Store) {
+	for _, message := range []*models.DirectMessage{
+		{ID: "m1", FromID: "alice", ToID: "bob", Timestamp: 100},
+		{ID: "m2", FromID: "bob", ToID: "alice", Timestamp: 101, ReplyToID: "m1"},
+		{ID: "m3", FromID: "carol", ToID: "alice", Timestamp: 102},
+		{ID: "m4", FromID: "alice", ToID: "bob", Timestamp: 103},
+		{ID: "m5", FromID: "alice", ToID: "alice", Timestamp: 104},
+	} {
+		if err := s.SendMessage(message); err != nil {
+			t.Fatalf("Failed to send message %s: %v", message.ID, err)
+		}
+	}
+	if err := s.SendMessage(&models.DirectMessage{ID: "m1", FromID: "bob", ToID: "carol"}); err == nil {
+		t.Error("Expected an error when sending a duplicate message")
+	}
+
+	ids := func(messages []*models.DirectMessage, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Failed to get messages: %v", err)
+		}
+		var ids []string
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+	if got := ids(s.GetSentMessages("alice")); got != "m1,m4,m5" {
+		t.Errorf("Expected alice to have sent m1,m4,m5, got %s", got)
+	}
+	if got := ids(s.GetConversation("alice", "bob")); got != "m1,m2,m4" {
+		t.Errorf("Expected the conversation m1,m2,m4, got %s", got)
+	}
+	if got := ids(s.GetConversation("bob", "alice")); got != "m1,m2,m4" {
+		t.Errorf("Expected bob to see the same conversation, got %s", got)
+	}
+	if got := ids(s.GetConversation("alice", "alice")); got != "m5" {
+		t.Errorf("Expected a note to self once, got %s", got)
+	}
+
+	before, _ := s.GetMessages("bob")
+	if err := s.MarkRead("bob", []string{"m1", "m4"}); err != nil {
+		t.Fatalf("Failed to mark messages read: %v", err)
+	}
+	if err := s.MarkRead("bob", []string{"m1", "m4"}); err != nil {
+		t.Errorf("Expected marking read twice to succeed, got %v", err)
+	}
+	// Only the recipient can mark a message read, and a bad ID marks nothing.
+	if err := s.MarkRead("alice", []string{"m3", "m1"}); err == nil {
+		t.Error("Expected an error marking another user's message read")
+	}
+	if err := s.MarkRead("alice", []string{"missing"}); err == nil {
+		t.Error("Expected an error for an unknown message")
+	}
+
+	inbox, _ := s.GetMessages("bob")
+	if !inbox[0].Read || !inbox[1].Read || before[0].Read {
+		t.Errorf("Expected bob's messages to be read without changing earlier reads, got %+v", inbox)
+	}
+	sent, _ := s.GetSentMessages("alice")
+	if !sent[0].Read || sent[2].Read {
+		t.Errorf("Expected the sender to see read receipts, got %+v", sent)
+	}
+	if received, _ := s.GetMessages("alice"); received[1].Read {
+		t.Errorf("Expected m3 to stay unread after a failed MarkRead, got %+v", received[1])
+	}
+}
+
+func testVotes(t *testing.T, s store.Store) {
+	for _, id := range []string{"author", "commenter"} {
+		if err := s.CreateUser(&models.User{ID: id, Username: id}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.AddComment(&models.Comment{ID: "c1", PostID: "post1", AuthorID: "commenter"}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	for _, vote := range []struct {
+		target string
+		user   string
+		upvote bool
+	}{
+		{"post1", "user2", true}, {"post1", "user3", true}, {"post1", "user4", false},
+		{"c1", "user2", false}, {"c1", "user3", false}, {"c1", "user4", true}, {"c1", "user5", false},
+	} {
+		if err := s.Vote(vote.target, vote.user, vote.upvote); err != nil {
+			t.Fatalf("Failed to vote: %v", err)
+		}
+	}
+
+	post, err := s.GetPost("post1")
+	if err != nil {
+		t.Fatalf("Failed to get post: %v", err)
+	}
+	if post.Karma != 1 {
+		t.Errorf("Expected post karma 1 after two upvotes and one downvote, got %d", post.Karma)
+	}
+
+	comments, err := s.GetComments("post1")
+	if err != nil || len(comments) != 1 {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if comments[0].Karma != -2 {
+		t.Errorf("Expected comment karma -2 after one upvote and three downvotes, got %d", comments[0].Karma)
+	}
+
+	// The authors' karma is credited separately.
+	author, err := s.GetUser("author")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if author.Karma != 0 {
+		t.Errorf("Expected votes to leave the author's karma alone, got %d", author.Karma)
+	}
+	if err := s.AddKarma("author", 1, 0); err != nil {
+		t.Fatalf("Failed to add karma: %v", err)
+	}
+	if err := s.AddKarma("commenter", 0, -2); err != nil {
+		t.Fatalf("Failed to add karma: %v", err)
+	}
+	var notFound *store.NotFoundError
+	if err := s.AddKarma("missing", 1, 0); !errors.As(err, &notFound) {
+		t.Errorf("Expected a NotFoundError adding karma to a missing user, got %v", err)
+	}
+
+	author, err = s.GetUser("author")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if author.PostKarma != 1 || author.CommentKarma != 0 || author.Karma != 1 {
+		t.Errorf("Expected post author karma 1/0/1, got %d/%d/%d", author.PostKarma, author.CommentKarma, author.Karma)
+	}
+
+	commenter, err := s.GetUser("commenter")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if commenter.PostKarma != 0 || commenter.CommentKarma != -2 || commenter.Karma != -2 {
+		t.Errorf("Expected commenter karma 0/-2/-2, got %d/%d/%d", commenter.PostKarma, commenter.CommentKarma, commenter.Karma)
+	}
+}
+
+func testVoteChanges(t *testing.T, s store.Store) {
+	if err := s.CreateUser(&models.User{ID: "author", Username: "author"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	steps := []struct {
+		name  string
+		apply func() error
+		karma int32
+		ups   int32
+		downs int32
+	}{
+		{"upvote", func() error { return s.Vote("post1", "user2", true) }, 1, 1, 0},
+		{"repeated upvote", func() error { return s.Vote("post1", "user2", true) }, 1, 1, 0},
+		{"switch to downvote", func() error { return s.Vote("post1", "user2", false) }, -1, 0, 1},
+		{"second voter", func() error { return s.Vote("post1", "user3", true) }, 0, 1, 1},
+		{"clear", func() error { return s.ClearVote("post1", "user2") }, 1, 1, 0},
+		{"clear again", func() error { return s.ClearVote("post1", "user2") }, 1, 1, 0},
+		{"clear without vote", func() error { return s.ClearVote("post1", "user4") }, 1, 1, 0},
+	}
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		post, err := s.GetPost("post1")
+		if err != nil {
+			t.Fatalf("Failed to get post: %v", err)
+		}
+		if post.Karma != step.karma || post.Ups != step.ups || post.Downs != step.downs {
+			t.Errorf("%s: expected karma %d (%d/%d), got %d (%d/%d)",
+				step.name, step.karma, step.ups, step.downs, post.Karma, post.Ups, post.Downs)
+		}
+	}
+}
+
+// testConcurrentVotes has many users vote, flip and retract at once and checks
+// that the stored score matches the votes each user was left holding.
+func testConcurrentVotes(t *testing.T, s store.Store) {
+	const (
+		voters = 16
+		rounds = 20
+	)
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	final := make([]int32, voters) // +1, -1 or 0 for no vote
+	errs := make(chan error, voters)
+	var wg sync.WaitGroup
+	for v := 0; v < voters; v++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			userID := fmt.Sprintf("voter%d", v)
+			for r := 0; r < rounds; r++ {
+				var err error
+				switch (v + r) % 4 {
+				case 0, 1:
+					err = s.Vote("post1", userID, true)
+					final[v] = 1
+				case 2:
+					err = s.Vote("post1", userID, false)
+					final[v] = -1
+				case 3:
+					err = s.ClearVote("post1", userID)
+					final[v] = 0
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(v)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+
+	var want, ups, downs int32
+	for _, vote := range final {
+		want += vote
+		if vote > 0 {
+			ups++
+		} else if vote < 0 {
+			downs++
+		}
+	}
+	post, err := s.GetPost("post1")
+	if err != nil {
+		t.Fatalf("Failed to get post: %v", err)
+	}
+	if post.Karma != want || post.Ups != ups || post.Downs != downs {
+		t.Errorf("Expected karma %d (%d/%d), got %d (%d/%d)", want, ups, downs, post.Karma, post.Ups, post.Downs)
+	}
+}
+
+// testOrdering checks that listings come back oldest first with ties broken
+// by ID, whatever order the items were written in.
+func testOrdering(t *testing.T, s store.Store) {
+	for _, post := range []*models.Post{
+		{ID: "p3", SubredditID: "sub1", Created: 30},
+		{ID: "p1", SubredditID: "sub1", Created: 10},
+		{ID: "p2b", SubredditID: "sub1", Created: 20},
+		{ID: "p2a", SubredditID: "sub1", Created: 20},
+	} {
+		if err := s.CreatePost(post); err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+	}
+	for _, comment := range []*models.Comment{
+		{ID: "c2", PostID: "p1", Created: 2},
+		{ID: "c3", PostID: "p1", Created: 3},
+		{ID: "c1", PostID: "p1", Created: 1},
+	} {
+		if err := s.AddComment(comment); err != nil {
+			t.Fatalf("Failed to add comment: %v", err)
+		}
+	}
+	for _, message := range []*models.DirectMessage{
+		{ID: "m2", ToID: "user1", Timestamp: 2},
+		{ID: "m1", ToID: "user1", Timestamp: 1},
+		{ID: "m3", ToID: "user1", Timestamp: 2},
+	} {
+		if err := s.SendMessage(message); err != nil {
+			t.Fatalf("Failed to send message: %v", err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		posts, err := s.GetSubredditPosts("sub1")
+		if err != nil {
+			t.Fatalf("Failed to get subreddit posts: %v", err)
+		}
+		var postIDs []string
+		for _, post := range posts {
+			postIDs = append(postIDs, post.ID)
+		}
+		if got := fmt.Sprint(postIDs); got != "[p1 p2a p2b p3]" {
+			t.Errorf("Expected posts [p1 p2a p2b p3], got %s", got)
+		}
+	}
+
+	comments, err := s.GetComments("p1")
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	var commentIDs []string
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	if got := fmt.Sprint(commentIDs); got != "[c1 c2 c3]" {
+		t.Errorf("Expected comments [c1 c2 c3], got %s", got)
+	}
+
+	messages, err := s.GetMessages("user1")
+	if err != nil {
+		t.Fatalf("Failed to get messages: %v", err)
+	}
+	var messageIDs []string
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.ID)
+	}
+	if got := fmt.Sprint(messageIDs); got != "[m1 m2 m3]" {
+		t.Errorf("Expected messages [m1 m2 m3], got %s", got)
+	}
+}
+
+func testUserIndexes(t *testing.T, s store.Store) {
+	if err := s.CreateSubreddit(&models.Subreddit{ID: "sub1", Name: "sub1", Members: map[string]bool{"user1": true}}); err != nil {
+		t.Fatalf("Failed to create subreddit: %v", err)
+	}
+	mustCreateSubreddit(t, s, "sub2")
+	mustCreateSubreddit(t, s, "sub3")
+	for _, subredditID := range []string{"sub3", "sub2"} {
+		if err := s.JoinSubreddit(subredditID, "user1"); err != nil {
+			t.Fatalf("Failed to join subreddit: %v", err)
+		}
+	}
+	if err := s.LeaveSubreddit("sub2", "user1"); err != nil {
+		t.Fatalf("Failed to leave subreddit: %v", err)
+	}
+
+	subscriptions, err := s.GetUserSubreddits("user1")
+	if err != nil {
+		t.Fatalf("Failed to get subscriptions: %v", err)
+	}
+	if got := fmt.Sprint(subscriptions); got != "[sub1 sub3]" {
+		t.Errorf("Expected subscriptions [sub1 sub3], got %s", got)
+	}
+	if none, err := s.GetUserSubreddits("nobody"); err != nil || none == nil || len(none) != 0 {
+		t.Errorf("Expected a non-nil empty subscription list, got %v (%v)", none, err)
+	}
+
+	// Subreddits stored elsewhere are recorded on the user's side only.
+	for _, subredditID := range []string{"remote", "sub2"} {
+		if err := s.SetSubscribed("user1", subredditID, true); err != nil {
+			t.Fatalf("Failed to record subscription: %v", err)
+		}
+	}
+	if err := s.SetSubscribed("user1", "sub2", false); err != nil {
+		t.Fatalf("Failed to record subscription: %v", err)
+	}
+	subscriptions, err = s.GetUserSubreddits("user1")
+	if err != nil {
+		t.Fatalf("Failed to get subscriptions: %v", err)
+	}
+	if got := fmt.Sprint(subscriptions); got != "[remote sub1 sub3]" {
+		t.Errorf("Expected subscriptions [remote sub1 sub3], got %s", got)
+	}
+	if subreddit, err := s.GetSubreddit("sub2"); err != nil || subreddit.Members["user1"] {
+		t.Errorf("Expected SetSubscribed to leave the members of sub2 alone, got %v (%v)", subreddit, err)
+	}
+
+	for _, post := range []*models.Post{
+		{ID: "p2", SubredditID: "sub1", AuthorID: "user1", Created: 2},
+		{ID: "p1", SubredditID: "sub3", AuthorID: "user1", Created: 1},
+		{ID: "p3", SubredditID: "sub1", AuthorID: "user2", Created: 3},
+	} {
+		if err := s.CreatePost(post); err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+	}
+	for _, comment := range []*models.Comment{
+		{ID: "c1", PostID: "p3", AuthorID: "user1", Created: 4},
+		{ID: "c2", PostID: "p1", AuthorID: "user2", Created: 5},
+	} {
+		if err := s.AddComment(comment); err != nil {
+			t.Fatalf("Failed to add comment: %v", err)
+		}
+	}
+
+	posts, err := s.GetUserPosts("user1")
+	if err != nil {
+		t.Fatalf("Failed to get user posts: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != "p1" || posts[1].ID != "p2" {
+		t.Errorf("Expected user1's posts [p1 p2], got %d posts", len(posts))
+	}
+
+	comments, err := s.GetUserComments("user1")
+	if err != nil {
+		t.Fatalf("Failed to get user comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ID != "c1" {
+		t.Errorf("Expected user1's comments [c1], got %d comments", len(comments))
+	}
+
+	if none, err := s.GetUserPosts("nobody"); err != nil || none == nil || len(none) != 0 {
+		t.Errorf("Expected a non-nil empty post list, got %v (%v)", none, err)
+	}
+}
+
+func testSnapshots(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+	if err := s.CreateUser(&models.User{ID: "author", Username: "author"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	post, _ := s.GetPost("post1")
+	posts, _ := s.GetSubredditPosts("sub1")
+	author, _ := s.GetUser("author")
+	subreddit, _ := s.GetSubreddit("sub1")
+
+	if err := s.Vote("post1", "user2", true); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	if err := s.AddKarma("author", 1, 0); err != nil {
+		t.Fatalf("Failed to add karma: %v", err)
+	}
+	if err := s.JoinSubreddit("sub1", "user2"); err != nil {
+		t.Fatalf("Failed to join subreddit: %v", err)
+	}
+
+	if post.Karma != 0 || posts[0].Karma != 0 || author.Karma != 0 {
+		t.Errorf("Expected earlier reads to keep karma 0, got post %d, listed post %d, author %d", post.Karma, posts[0].Karma, author.Karma)
+	}
+	if len(subreddit.Members) != 0 {
+		t.Errorf("Expected an earlier read to keep no members, got %v", subreddit.Members)
+	}
+
+	// Listings return the new version.
+	posts, _ = s.GetSubredditPosts("sub1")
+	if len(posts) != 1 || posts[0].Karma != 1 {
+		t.Errorf("Expected the listed post to have karma 1, got %+v", posts)
+	}
+}
+
+func testModeration(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author"}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.AddComment(&models.Comment{ID: "c1", PostID: "post1", AuthorID: "author", Content: "spam"}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	for _, err := range []error{
+		s.AddModerator("sub1", "mod"),
+		s.SetBanned("sub1", "troll", true),
+		s.SetBanned("sub1", "user2", true),
+		s.SetBanned("sub1", "user2", false),
+		s.RemoveComment("c1"),
+		s.SetPostLocked("post1", true),
+	} {
+		if err != nil {
+			t.Fatalf("Moderation operation failed: %v", err)
+		}
+	}
+
+	subreddit, err := s.GetSubreddit("sub1")
+	if err != nil {
+		t.Fatalf("Failed to get subreddit: %v", err)
+	}
+	if !subreddit.Moderators["mod"] || !subreddit.Banned["troll"] || subreddit.Banned["user2"] {
+		t.Errorf("Unexpected moderators %v and bans %v", subreddit.Moderators, subreddit.Banned)
+	}
+	post, _ := s.GetPost("post1")
+	if !post.Locked || post.Removed {
+		t.Errorf("Expected post1 to be locked and not removed, got %+v", post)
+	}
+	if err := s.RemovePost("post1"); err != nil {
+		t.Fatalf("Failed to remove post: %v", err)
+	}
+	posts, _ := s.GetSubredditPosts("sub1")
+	if len(posts) != 1 || !posts[0].Removed {
+		t.Errorf("Expected the listed post to be marked removed, got %+v", posts)
+	}
+	comment, _ := s.GetComment("c1")
+	if !comment.Removed || comment.Content != "spam" {
+		t.Errorf("Expected c1 to be marked removed and keep its content, got %+v", comment)
+	}
+
+	for _, err := range []error{
+		s.AddModerator("missing", "mod"),
+		s.SetBanned("missing", "troll", true),
+		s.RemovePost("missing"),
+		s.RemoveComment("missing"),
+		s.SetPostLocked("missing", true),
+	} {
+		var notFound *store.NotFoundError
+		if !errors.As(err, &notFound) || notFound.ID != "missing" {
+			t.Errorf("Expected a NotFoundError for a missing target, got %v", err)
+		}
+	}
+
+	for _, action := range []*models.ModAction{
+		{ID: "a2", SubredditID: "sub1", ModeratorID: "mod", Action: models.ModLock, TargetID: "post1", Created: 20},
+		{ID: "a1", SubredditID: "sub1", ModeratorID: "mod", Action: models.ModBan, TargetID: "troll", Reason: "spam", Created: 10},
+		{ID: "a3", SubredditID: "sub2", ModeratorID: "mod", Action: models.ModBan, TargetID: "troll", Created: 30},
+	} {
+		if err := s.AddModAction(action); err != nil {
+			t.Fatalf("Failed to add moderation action: %v", err)
+		}
+	}
+	log, err := s.GetModLog("sub1")
+	if err != nil {
+		t.Fatalf("Failed to get moderation log: %v", err)
+	}
+	if len(log) != 2 || log[0].ID != "a1" || log[1].ID != "a2" || log[0].Reason != "spam" {
+		t.Errorf("Expected a1, a2 in sub1's log, got %+v", log)
+	}
+	if empty, err := s.GetModLog("none"); err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("Expected a non-nil empty log, got %v (%v)", empty, err)
+	}
+}
+
+func mustCreateSubreddit(t *testing.T, s store.Store, id string) {
+	t.Helper()
+	if err := s.CreateSubreddit(&models.Subreddit{ID: id, Name: id, Members: make(map[string]bool)}); err != nil {
+		t.Fatalf("Failed to create subreddit %s: %v", id, err)
+	}
+}
+
+func testEdits(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "author", Content: "v1", Created: 10}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.AddComment(&models.Comment{ID: "c1", PostID: "post1", AuthorID: "author", Content: "first", Created: 15}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	if err := s.EditPost("post1", "v2", 20); err != nil {
+		t.Fatalf("Failed to edit post: %v", err)
+	}
+	edited, _ := s.GetPost("post1")
+	if err := s.EditPost("post1", "v3", 30); err != nil {
+		t.Fatalf("Failed to edit post: %v", err)
+	}
+
+	post, _ := s.GetPost("post1")
+	want := []models.Revision{{Content: "v1", Created: 10}, {Content: "v2", Created: 20}}
+	if post.Content != "v3" || post.Edited != 30 || fmt.Sprint(post.History) != fmt.Sprint(want) {
+		t.Errorf("Expected v3 edited at 30 with history %v, got %+v", want, post)
+	}
+	if edited.Content != "v2" || len(edited.History) != 1 {
+		t.Errorf("Expected an earlier read to keep v2 and one revision, got %+v", edited)
+	}
+
+	if err := s.EditComment("c1", "second", 25); err != nil {
+		t.Fatalf("Failed to edit comment: %v", err)
+	}
+	if err := s.DeleteComment("c1"); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+	comments, _ := s.GetComments("post1")
+	if len(comments) != 1 || !comments[0].Deleted || comments[0].Content != "second" || comments[0].Edited != 25 ||
+		len(comments[0].History) != 1 || comments[0].History[0].Content != "first" {
+		t.Errorf("Expected c1 to be edited, then deleted without losing content, got %+v", comments)
+	}
+
+	if err := s.DeletePost("post1"); err != nil {
+		t.Fatalf("Failed to delete post: %v", err)
+	}
+	posts, _ := s.GetUserPosts("author")
+	if len(posts) != 1 || !posts[0].Deleted || posts[0].Removed {
+		t.Errorf("Expected the listed post to be marked deleted, got %+v", posts)
+	}
+
+	for _, err := range []error{
+		s.EditPost("missing", "text", 40),
+		s.EditComment("missing", "text", 40),
+		s.DeletePost("missing"),
+		s.DeleteComment("missing"),
+	} {
+		if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for a missing target, got %v", err)
+		}
+	}
+}
+
+func testAccounts(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+	for _, user := range []*models.User{
+		{ID: "alice", Username: "alice", Password: "hash1", Created: 1},
+		{ID: "bob", Username: "bob", Created: 2},
+	} {
+		if err := s.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	if err := s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", AuthorID: "alice", Created: 10}); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := s.AddComment(&models.Comment{ID: "c1", PostID: "post1", AuthorID: "alice", Created: 11}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	if err := s.Vote("post1", "bob", true); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	if err := s.Vote("c1", "bob", false); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	if err := s.Vote("c1", "alice", true); err != nil {
+		t.Fatalf("Failed to vote: %v", err)
+	}
+	if err := s.ClearVote("c1", "alice"); err != nil {
+		t.Fatalf("Failed to clear vote: %v", err)
+	}
+
+	votes, _ := s.GetUserVotes("bob")
+	if fmt.Sprint(votes) != fmt.Sprint(map[string]bool{"c1": false, "post1": true}) {
+		t.Errorf("Expected bob's two votes, got %v", votes)
+	}
+	if votes, _ := s.GetUserVotes("alice"); len(votes) != 0 {
+		t.Errorf("Expected a cleared vote not to be listed, got %v", votes)
+	}
+
+	if err := s.UpdateProfile("alice", "Alice", "Gopher"); err != nil {
+		t.Fatalf("Failed to update profile: %v", err)
+	}
+	if err := s.SetPassword("alice", "hash2"); err != nil {
+		t.Fatalf("Failed to set password: %v", err)
+	}
+	user, _ := s.GetUser("alice")
+	if user.DisplayName != "Alice" || user.Bio != "Gopher" || user.Password != "hash2" || user.Username != "alice" {
+		t.Errorf("Expected the updated profile and password, got %+v", user)
+	}
+
+	if err := s.DeleteUser("alice"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	user, _ = s.GetUser("alice")
+	if !user.Deleted || user.Username != "" || user.Password != "" || user.DisplayName != "" || user.Bio != "" {
+		t.Errorf("Expected a cleared, deleted account, got %+v", user)
+	}
+	if posts, _ := s.GetUserPosts("alice"); len(posts) != 0 {
+		t.Errorf("Expected no posts left under alice, got %v", posts)
+	}
+	if comments, _ := s.GetUserComments("alice"); len(comments) != 0 {
+		t.Errorf("Expected no comments left under alice, got %v", comments)
+	}
+	if posts, _ := s.GetUserPosts(models.DeletedAuthor); len(posts) != 1 || posts[0].ID != "post1" {
+		t.Errorf("Expected post1 to be credited to %s, got %v", models.DeletedAuthor, posts)
+	}
+	if posts, _ := s.GetSubredditPosts("sub1"); len(posts) != 1 || posts[0].AuthorID != models.DeletedAuthor || posts[0].Karma != 1 {
+		t.Errorf("Expected the listed post to keep its karma under %s, got %+v", models.DeletedAuthor, posts)
+	}
+	if comments, _ := s.GetComments("post1"); len(comments) != 1 || comments[0].AuthorID != models.DeletedAuthor {
+		t.Errorf("Expected c1 to be credited to %s, got %+v", models.DeletedAuthor, comments)
+	}
+	if err := s.CreateUser(&models.User{ID: "alice", Username: "again"}); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("Expected a deleted user's ID to stay taken, got %v", err)
+	}
+
+	// Content of a user stored elsewhere is credited without the account.
+	if err := s.AddComment(&models.Comment{ID: "c2", PostID: "post1", AuthorID: "remote", Created: 12}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	if err := s.CreditToDeleted("remote"); err != nil {
+		t.Fatalf("Failed to credit content: %v", err)
+	}
+	if comments, _ := s.GetUserComments(models.DeletedAuthor); len(comments) != 2 {
+		t.Errorf("Expected c1 and c2 to be credited to %s, got %d comments", models.DeletedAuthor, len(comments))
+	}
+	if err := s.CreditToDeleted("nobody"); err != nil {
+		t.Errorf("Expected crediting a user without content to succeed, got %v", err)
+	}
+
+	for _, err := range []error{
+		s.UpdateProfile("missing", "", ""),
+		s.SetPassword("missing", "hash"),
+		s.DeleteUser("missing"),
+	} {
+		if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for a missing user, got %v", err)
+		}
+	}
+}
+
+func testSessions(t *testing.T, s store.Store) {
+	if err := s.CreateUser(&models.User{ID: "alice", Username: "alice"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	if err := s.RevokeSessions("alice", 1_000); err != nil {
+		t.Fatalf("Failed to revoke sessions: %v", err)
+	}
+	if err := s.EndSession("alice", "t1", 100, 50); err != nil {
+		t.Fatalf("Failed to end session: %v", err)
+	}
+	before, _ := s.GetUser("alice")
+	if err := s.EndSession("alice", "t2", 300, 100); err != nil {
+		t.Fatalf("Failed to end session: %v", err)
+	}
+	user, _ := s.GetUser("alice")
+	if user.SessionsRevoked != 1_000 {
+		t.Errorf("Expected sessions revoked before 1000, got %d", user.SessionsRevoked)
+	}
+	if fmt.Sprint(user.LoggedOut) != fmt.Sprint(map[string]int64{"t2": 300}) {
+		t.Errorf("Expected only the unexpired logout to be kept, got %v", user.LoggedOut)
+	}
+	if _, ok := before.LoggedOut["t1"]; !ok || len(before.LoggedOut) != 1 {
+		t.Errorf("Expected an earlier copy of the user to be unaffected, got %v", before.LoggedOut)
+	}
+
+	for tokenID, want := range map[string]bool{"t2": true, "t3": false} {
+		if got := store.SessionRevoked(user, tokenID, 2_000); got != want {
+			t.Errorf("SessionRevoked(%s) = %v, want %v", tokenID, got, want)
+		}
+	}
+	if !store.SessionRevoked(user, "t3", 999) {
+		t.Error("Expected a session issued before the revocation to be revoked")
+	}
+
+	if err := s.DeleteUser("alice"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	user, _ = s.GetUser("alice")
+	if !store.SessionRevoked(user, "t3", 2_000) {
+		t.Error("Expected every session of a deleted user to be revoked")
+	}
+
+	for _, err := range []error{
+		s.RevokeSessions("missing", 1),
+		s.EndSession("missing", "t1", 100, 50),
+	} {
+		if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for a missing user, got %v", err)
+		}
+	}
+}
+
+func testActivity(t *testing.T, s store.Store) {
+	mustCreateSubreddit(t, s, "sub1")
+	mustCreateSubreddit(t, s, "sub10")
+	for _, err := range []error{
+		s.CreatePost(&models.Post{ID: "post1", SubredditID: "sub1", Created: 10}),
+		s.CreatePost(&models.Post{ID: "post2", SubredditID: "sub1", Created: 20}),
+		s.CreatePost(&models.Post{ID: "post3", SubredditID: "sub1", Created: 25, Removed: true}),
+		s.CreatePost(&models.Post{ID: "other", SubredditID: "sub10", Created: 99}),
+		s.AddComment(&models.Comment{ID: "c1", PostID: "post1", Created: 30}),
+		s.AddComment(&models.Comment{ID: "c2", PostID: "post2", Created: 40}),
+		s.AddComment(&models.Comment{ID: "c3", PostID: "post1", Created: 50}),
+		s.AddComment(&models.Comment{ID: "c4", PostID: "post3", Created: 60}),
+	} {
+		if err != nil {
+			t.Fatalf("Failed to fill the store: %v", err)
+		}
+	}
+
+	check := func(name string, since int64, wantRecent int, wantLast int64) {
+		t.Helper()
+		recent, last, err := s.GetSubredditActivity("sub1", since)
+		if err != nil {
+			t.Fatalf("%s: failed to get activity: %v", name, err)
+		}
+		if recent != wantRecent || last != wantLast {
+			t.Errorf("%s: expected %d since %d and the latest at %d, got %d and %d", name, wantRecent, since, wantLast, recent, last)
+		}
+	}
+	check("all", 0, 5, 50)
+	check("since", 30, 3, 50)
+	check("inclusive", 50, 1, 50)
+	check("future", 51, 0, 50)
+
+	if err := s.RemoveComment("c3"); err != nil {
+		t.Fatalf("Failed to remove comment: %v", err)
+	}
+	check("removed comment", 0, 4, 40)
+	if err := s.DeletePost("post2"); err != nil {
+		t.Fatalf("Failed to delete post: %v", err)
+	}
+	check("deleted post and its comment", 0, 2, 30)
+	if err := s.EditPost("post1", "edited", 70); err != nil {
+		t.Fatalf("Failed to edit post: %v", err)
+	}
+	check("edits are not activity", 0, 2, 30)
+
+	if recent, last, err := s.GetSubredditActivity("missing", 0); err != nil || recent != 0 || last != 0 {
+		t.Errorf("Expected no activity in an unknown subreddit, got %d, %d (%v)", recent, last, err)
+	}
+}
@@ -26,6 +26,8 @@ type RedditMetrics struct {
 	SimulatedUsers      prometheus.Gauge
 	AverageResponseTime prometheus.Gauge
 	ErrorRate           prometheus.Gauge
+	ActorRestarts       *prometheus.CounterVec
+	ErrorsByCode        *prometheus.CounterVec
 }
 
 type PersonaStats struct {
@@ -90,6 +92,14 @@ func NewRedditMetrics() *RedditMetrics {
 				Name: "reddit_error_rate",
 				Help: "Rate of errors per second",
 			}),
+			ActorRestarts: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "reddit_actor_restarts_total",
+				Help: "Supervisor decisions about failed engine actors",
+			}, []string{"actor", "directive"}),
+			ErrorsByCode: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "reddit_errors_by_code_total",
+				Help: "Failed requests by error code",
+			}, []string{"code"}),
 		}
 
 	})
@@ -176,6 +186,18 @@ func (m *RedditMetrics) RecordError() {
 	log.Println("An error occurred")
 }
 
+// RecordErrorCode records an error with its code, e.g. "NOT_FOUND"
+func (m *RedditMetrics) RecordErrorCode(code string) {
+	m.ErrorsByCode.WithLabelValues(code).Inc()
+	m.RecordError()
+}
+
+// RecordActorFailure counts a supervisor decision, e.g. "restart" or "stop",
+// about a failed actor of the given kind
+func (m *RedditMetrics) RecordActorFailure(actor string, directive string) {
+	m.ActorRestarts.WithLabelValues(actor, directive).Inc()
+}
+
 // RecordRequest records the duration of a request
 func (m *RedditMetrics) RecordRequest(duration float64) {
 	m.ResponseTime.Observe(duration)